@@ -0,0 +1,158 @@
+// Clash Royale TCR Replay Player - Main Entry Point
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"tcr-game/internal/client"
+	"tcr-game/internal/game"
+	"tcr-game/pkg/logger"
+)
+
+var (
+	file     = flag.String("file", "", "Replay file path (data/replays/<gameID>.jsonl)")
+	speed    = flag.Float64("speed", 1, "Playback speed multiplier")
+	start    = flag.String("start", "0", "Start playback at this match time (seconds or m:ss)")
+	pov      = flag.Int("pov", 1, "Render from the point of view of player 1 or 2")
+	paused   = flag.Bool("paused", false, "Start playback paused")
+	verify   = flag.Bool("verify", false, "Re-simulate the match and fail if the final state diverges")
+	logLevel = flag.String("log-level", "WARN", "Log level (DEBUG, INFO, WARN, ERROR)")
+	logFile  = flag.String("log-file", "", "Log file path (optional)")
+)
+
+func main() {
+	flag.Parse()
+
+	if *file == "" && flag.NArg() > 0 {
+		*file = flag.Arg(0)
+	}
+	if *file == "" {
+		fmt.Fprintf(os.Stderr, "Usage: replay [flags] <replay file>\n")
+		flag.PrintDefaults()
+		os.Exit(2)
+	}
+
+	// Initialize logging
+	if err := initLogging(); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to initialize logging: %v\n", err)
+		os.Exit(1)
+	}
+
+	replay, err := game.LoadReplay(*file)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load replay: %v\n", err)
+		os.Exit(1)
+	}
+
+	if replay.Header.Specs != nil {
+		if specsVersion := game.SpecsVersion(replay.Header.Specs); specsVersion != replay.Header.SpecsVersion {
+			fmt.Fprintf(os.Stderr, "Warning: embedded specs hash to %s, header says %s\n",
+				specsVersion, replay.Header.SpecsVersion)
+		}
+	}
+
+	// Re-run the match and play back the events it produces
+	var recording bytes.Buffer
+	finalState, err := game.RunReplay(replay, game.NewReplayWriter(&recording))
+	if err != nil {
+		if *verify {
+			fmt.Fprintf(os.Stderr, "FAIL: %v\n", err)
+		} else {
+			fmt.Fprintf(os.Stderr, "Re-simulation failed: %v\n", err)
+		}
+		os.Exit(1)
+	}
+
+	if *verify {
+		os.Exit(verifyReplay(replay, finalState))
+	}
+
+	rerun, err := game.ReadReplay(&recording)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to read re-simulated match: %v\n", err)
+		os.Exit(1)
+	}
+
+	startAt, err := client.ParseMatchTime(*start)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid -start: %v\n", err)
+		os.Exit(2)
+	}
+
+	client.NewReplayPlayer(rerun, *pov, *speed).Play(startAt, *paused)
+}
+
+// verifyReplay compares the re-simulated final state with the recorded one
+// and returns the process exit code
+func verifyReplay(replay *game.Replay, finalState *game.GameState) int {
+	if replay.FinalState == nil {
+		fmt.Fprintf(os.Stderr, "FAIL: replay has no recorded final state (match did not finish recording)\n")
+		return 1
+	}
+
+	sections := []struct {
+		name            string
+		recorded, rerun interface{}
+	}{
+		{"player1", replay.FinalState.Player1, finalState.Player1},
+		{"player2", replay.FinalState.Player2, finalState.Player2},
+		{"towers_killed", replay.FinalState.TowersKilled, finalState.TowersKilled},
+		{"winner", replay.FinalState.Winner, finalState.Winner},
+		{"status", replay.FinalState.Status, finalState.Status},
+		{"time_left", replay.FinalState.TimeLeft, finalState.TimeLeft},
+		{"current_turn", replay.FinalState.CurrentTurn, finalState.CurrentTurn},
+	}
+
+	diverged := false
+	for _, section := range sections {
+		recorded, _ := json.Marshal(section.recorded)
+		rerun, _ := json.Marshal(section.rerun)
+		if !bytes.Equal(recorded, rerun) {
+			diverged = true
+			fmt.Fprintf(os.Stderr, "DIVERGED %s\n  recorded: %s\n  re-run:   %s\n", section.name, recorded, rerun)
+		}
+	}
+
+	if diverged {
+		fmt.Fprintf(os.Stderr, "FAIL: %s diverged from the recording\n", replay.Header.GameID)
+		return 1
+	}
+
+	fmt.Printf("OK: %s (%s, seed %d, %d commands) re-simulates to the recorded final state\n",
+		replay.Header.GameID, replay.Header.GameMode, replay.Header.Seed, len(replay.Commands))
+	return 0
+}
+
+// initLogging sets up the logging system
+func initLogging() error {
+	// Set log level
+	var level logger.LogLevel
+	switch *logLevel {
+	case "DEBUG":
+		level = logger.DEBUG
+	case "INFO":
+		level = logger.INFO
+	case "WARN":
+		level = logger.WARN
+	case "ERROR":
+		level = logger.ERROR
+	default:
+		level = logger.WARN
+	}
+
+	logger.SetGlobalLogLevel(level)
+
+	// Keep engine logs off the playback screen when writing to a file
+	if *logFile != "" {
+		if err := logger.Server.SetFile(*logFile); err != nil {
+			return fmt.Errorf("failed to set log file: %w", err)
+		}
+		logger.Server.SetConsole(false)
+	}
+
+	return nil
+}
@@ -21,6 +21,11 @@ var (
 	dataDir   = flag.String("data-dir", "data", "Data directory path")
 	logLevel  = flag.String("log-level", "INFO", "Log level (DEBUG, INFO, WARN, ERROR)")
 	logFile   = flag.String("log-file", "", "Log file path (optional)")
+
+	simpleRules         = flag.String("simple-rules", game.ModeSimple, "Ruleset from rules.json played in Simple mode")
+	enhancedRules       = flag.String("enhanced-rules", game.ModeEnhanced, "Ruleset from rules.json played in Enhanced mode")
+	correspondenceRules = flag.String("correspondence-rules", game.QueueCorrespondence, "Ruleset from rules.json played in correspondence games, empty turns them off")
+	reconnectGrace      = flag.Duration("reconnect-grace", server.DefaultReconnectGrace, "How long a player who drops out of a match has to log back in, 0 forfeits them at once")
 )
 
 func main() {
@@ -45,6 +50,18 @@ func main() {
 	// Create server
 	address := fmt.Sprintf("%s:%s", *host, *port)
 	gameServer := server.NewServer(address, dataManager)
+	if err := gameServer.UseRuleset(game.ModeSimple, *simpleRules); err != nil {
+		logger.Server.Fatal("Failed to select Simple mode ruleset: %v", err)
+	}
+	if err := gameServer.UseRuleset(game.ModeEnhanced, *enhancedRules); err != nil {
+		logger.Server.Fatal("Failed to select Enhanced mode ruleset: %v", err)
+	}
+	if *correspondenceRules != "" {
+		if err := gameServer.UseRuleset(game.QueueCorrespondence, *correspondenceRules); err != nil {
+			logger.Server.Fatal("Failed to select correspondence ruleset: %v", err)
+		}
+	}
+	gameServer.SetReconnectGrace(*reconnectGrace)
 
 	// Setup graceful shutdown
 	setupGracefulShutdown(gameServer)
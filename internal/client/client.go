@@ -5,6 +5,7 @@ import (
 	"bufio"
 	"encoding/json"
 	"fmt"
+	"math"
 	"net"
 	"strconv"
 	"strings"
@@ -21,6 +22,7 @@ type Client struct {
 	display            *Display
 	input              *InputHandler
 	player             *game.PlayerData
+	deckSize           int // Troop cards in a deck, sent by the server on login
 	gameState          *game.GameState
 	myTroops           []game.Troop
 	myTowers           []game.Tower
@@ -38,6 +40,7 @@ type Client struct {
 	lastWaitingMessage string
 	troopDestroyedTower map[string]bool // Track if a troop destroyed a tower in its last attack
 	troopDestroyedKingTower map[string]bool // Track if a troop destroyed the King Tower in its last attack
+	gameList           chan []network.CorrespondenceGameInfo // Open correspondence games sent by the server
 }
 
 // NewClient creates a new client instance
@@ -56,6 +59,7 @@ func NewClient(serverAddr string) *Client {
 		deployedThisTurn: []string{},
 		troopDestroyedTower: make(map[string]bool),
 		troopDestroyedKingTower: make(map[string]bool),
+		gameList:         make(chan []network.CorrespondenceGameInfo, 1),
 	}
 }
 
@@ -74,6 +78,7 @@ func (c *Client) handleGameEnd(msg *network.Message) error {
 	c.logger.Debug("🎯 Game end data: %+v", gameEndData)
 
 	winner, _ := gameEndData["winner"].(string)
+	reason, _ := gameEndData["reason"].(string)
 	expGained, _ := gameEndData["exp_gained"].(string)
 	opponentExpGained, _ := gameEndData["opponent_exp_gained"].(string)
 
@@ -107,6 +112,9 @@ func (c *Client) handleGameEnd(msg *network.Message) error {
 		c.display.PrintInfo("💀 DEFEAT! You lost this battle.")
 		c.display.PrintInfo("Better luck next time!")
 	}
+	if reason != "" {
+		c.display.PrintInfo(fmt.Sprintf("📜 Decided by: %s", game.DescribeEndReason(reason)))
+	}
 
 	// Display EXP gains
 	c.display.PrintExperience(playerExp, opponentExp)
@@ -133,6 +141,11 @@ func (c *Client) handleGameEnd(msg *network.Message) error {
 
 // handleTurnChange processes turn changes
 func (c *Client) handleTurnChange(msg *network.Message) error {
+	// A correspondence game may have been left just before the turn passed
+	if c.gameState == nil {
+		return nil
+	}
+
 	currentTurn, _ := msg.Data["current_turn"].(string)
 
 	c.logger.Debug("Received turn change: %s -> %s", c.gameState.CurrentTurn, currentTurn)
@@ -152,6 +165,11 @@ func (c *Client) handleTurnChange(msg *network.Message) error {
 		// Clear any existing waiting messages
 		c.lastWaitingMessage = ""
 
+		// The player who just lost the turn let the clock run out
+		if reason, _ := msg.Data["reason"].(string); reason == game.TurnEndedByTimeout {
+			c.display.PrintTurnTimedOut(c.getPlayerName(c.getOpponentID()), currentTurn != c.clientID)
+		}
+
 		if currentTurn == c.clientID {
 			// Reset turn-specific state
 			c.deployedThisTurn = []string{}
@@ -165,13 +183,23 @@ func (c *Client) handleTurnChange(msg *network.Message) error {
 			c.display.PrintSeparator()
 			c.display.PrintInfo("🔥 It's YOUR TURN! 🔥")
 			c.display.PrintInfo("Available actions: play, attack, info, debug, end, surrender")
-			c.display.PrintInfo("💡 Remember: 1 troop deployment per turn, each deployed troop can attack once")
+			if c.gameState.Rules.ActionPoints > 0 {
+				c.display.PrintInfo(fmt.Sprintf("💡 Remember: %d action points per turn, cards cost their mana and attacks cost %d",
+					c.gameState.Rules.ActionPoints, game.AttackActionCost))
+			} else {
+				c.display.PrintInfo("💡 Remember: 1 troop deployment per turn, each deployed troop can attack once")
+			}
+			if c.gameState.TurnTimeLeft > 0 {
+				c.display.PrintInfo(fmt.Sprintf("⏱️ You have %d seconds to play this turn", c.gameState.TurnTimeLeft))
+			}
+			c.showTimeBanks()
 			c.display.PrintSeparator()
 		} else {
 			opponentName := c.getPlayerName(currentTurn)
 			c.display.PrintSeparator()
 			c.display.PrintInfo(fmt.Sprintf("⏳ Waiting for %s's turn...", opponentName))
 			c.display.PrintInfo("You can use 'info' or 'debug' to check game status")
+			c.showTimeBanks()
 			c.display.PrintSeparator()
 		}
 	}
@@ -189,6 +217,13 @@ func (c *Client) handleError(msg *network.Message) error {
 
 	message, _ := errorData["message"].(string)
 	c.display.PrintError(message)
+
+	// A rejected deck calls off the match search, a correspondence game that
+	// can't be saved or opened leaves nothing to wait for
+	switch code, _ := errorData["code"].(string); code {
+	case "INVALID_DECK", "MATCH_FAILED", "GAME_NOT_FOUND", "ALREADY_IN_GAME":
+		c.waitingForMatch = false
+	}
 	return nil
 }
 
@@ -241,6 +276,12 @@ func (c *Client) showProfile() {
 		c.display.PrintInfo(fmt.Sprintf("Win Rate: %.1f%%", winRate))
 	}
 
+	if deck := c.player.FindDeck(c.player.ActiveDeck); deck != nil {
+		c.display.PrintInfo(fmt.Sprintf("Active Deck: %s %v", deck.Name, deck.Cards))
+	} else {
+		c.display.PrintInfo("Active Deck: none (random troops)")
+	}
+
 	c.input.WaitForEnter("")
 }
 
@@ -260,9 +301,11 @@ func (c *Client) showGameStatus() {
 		} else {
 			myMana = c.gameState.Player2.Mana
 		}
-		c.display.PrintInfo(fmt.Sprintf("Your Mana: %d/%d", myMana, game.MaxMana))
+		c.display.PrintInfo(fmt.Sprintf("Your Mana: %d/%d", myMana, c.gameState.Rules.MaxMana))
 
-		c.display.PrintInfo("Mana regenerates +1 every second")
+		c.display.PrintInfo(fmt.Sprintf("Mana regenerates +%d every second", c.gameState.Rules.ManaRegenRate(c.gameState.Phase)))
+		c.showPhase()
+		c.showBattlefield()
 	}
 
 	if c.gameState.GameMode == game.ModeSimple {
@@ -271,9 +314,99 @@ func (c *Client) showGameStatus() {
 		} else {
 			c.display.PrintInfo("⏳ Opponent's Turn")
 		}
+		if c.gameState.TurnTimeLeft > 0 {
+			c.display.PrintInfo(fmt.Sprintf("Turn Time Left: %d seconds", c.gameState.TurnTimeLeft))
+		}
+		c.showTimeBanks()
+		c.showActionPoints()
 	}
 
 	c.display.PrintTroops(c.myTroops)
+	c.showHand()
+}
+
+// showTimeBanks prints both players' chess clocks when the ruleset has one (Simple mode)
+func (c *Client) showTimeBanks() {
+	if c.gameState.Rules.BankSeconds <= 0 {
+		return
+	}
+
+	mine, theirs := c.gameState.Player1.TimeBank, c.gameState.Player2.TimeBank
+	if c.gameState.Player2.ID == c.clientID {
+		mine, theirs = theirs, mine
+	}
+	c.display.PrintInfo(fmt.Sprintf("⏱️ Time Bank: You %s | Opponent %s (+%ds per turn)",
+		formatMatchTime(time.Duration(mine)*time.Second),
+		formatMatchTime(time.Duration(theirs)*time.Second),
+		c.gameState.Rules.IncrementSeconds))
+}
+
+// showActionPoints prints the action points left this turn when the ruleset has them (Simple mode)
+func (c *Client) showActionPoints() {
+	if c.gameState.Rules.ActionPoints <= 0 {
+		return
+	}
+	c.display.PrintInfo(fmt.Sprintf("🎲 Action Points: %d/%d (cards cost their mana, attacks cost %d)",
+		c.actionPointsLeft(), c.gameState.Rules.ActionPoints, game.AttackActionCost))
+}
+
+// actionPointsLeft returns the action points the player has left this turn (Simple mode)
+func (c *Client) actionPointsLeft() int {
+	if c.gameState.Player2.ID == c.clientID {
+		return c.gameState.Player2.ActionPoints
+	}
+	return c.gameState.Player1.ActionPoints
+}
+
+// checkCardBudget reports whether another card can be played this turn and
+// tells the player why not: action points when the ruleset has them,
+// otherwise the cards per turn limit (Simple mode)
+func (c *Client) checkCardBudget() bool {
+	if c.gameState.GameMode != game.ModeSimple {
+		return true
+	}
+
+	if c.gameState.Rules.ActionPoints > 0 {
+		if c.actionPointsLeft() <= 0 {
+			c.display.PrintError("No action points left this turn, end your turn")
+			return false
+		}
+		return true
+	}
+
+	if len(c.deployedThisTurn) >= c.gameState.Rules.CardsPerTurn {
+		c.display.PrintError(fmt.Sprintf("Cannot play more than %d card(s) per turn in simple mode", c.gameState.Rules.CardsPerTurn))
+		return false
+	}
+	return true
+}
+
+// showPhase prints the current match phase when it is not regular play (Enhanced mode)
+func (c *Client) showPhase() {
+	switch c.gameState.Phase {
+	case game.PhaseDoubleMana:
+		c.display.PrintInfo("⚡ DOUBLE MANA")
+	case game.PhaseOvertime:
+		c.display.PrintInfo("🔥 OVERTIME - the first tower destroyed wins!")
+	}
+}
+
+// showBattlefield prints the units both sides have on the field (Enhanced mode)
+func (c *Client) showBattlefield() {
+	if c.gameState.Player1.ID == c.clientID {
+		c.display.PrintBattlefield(c.gameState.Units.Player1, c.gameState.Units.Player2)
+	} else {
+		c.display.PrintBattlefield(c.gameState.Units.Player2, c.gameState.Units.Player1)
+	}
+}
+
+// showHand prints the cards that can be played now and the next card
+func (c *Client) showHand() {
+	if c.gameState.Player1.ID == c.clientID {
+		c.display.PrintHand(c.gameState.Player1.Hand, c.gameState.Player1.NextCard)
+	} else {
+		c.display.PrintHand(c.gameState.Player2.Hand, c.gameState.Player2.NextCard)
+	}
 }
 
 func (c *Client) showDetailedGameInfo() {
@@ -290,7 +423,11 @@ func (c *Client) showDetailedGameInfo() {
 		}
 
 		// Deployment status for Simple mode
-		c.display.PrintInfo(fmt.Sprintf("Troops Deployed This Turn: %d/1", len(c.deployedThisTurn)))
+		if c.gameState.Rules.ActionPoints > 0 {
+			c.showActionPoints()
+		} else {
+			c.display.PrintInfo(fmt.Sprintf("Troops Deployed This Turn: %d/%d", len(c.deployedThisTurn), c.gameState.Rules.CardsPerTurn))
+		}
 		if len(c.deployedThisTurn) > 0 {
 			c.display.PrintInfo(fmt.Sprintf("Deployed: %v", c.deployedThisTurn))
 		}
@@ -302,9 +439,10 @@ func (c *Client) showDetailedGameInfo() {
 			myMana = c.gameState.Player2.Mana
 		}
 
-		c.display.PrintInfo(fmt.Sprintf("⚡ Your Mana: %d/%d", myMana, game.MaxMana))
+		c.display.PrintInfo(fmt.Sprintf("⚡ Your Mana: %d/%d", myMana, c.gameState.Rules.MaxMana))
 		c.display.PrintInfo(fmt.Sprintf("⏰ Time Left: %d seconds", c.gameState.TimeLeft))
-		c.display.PrintInfo("🔄 Mana regenerates +1 every second")
+		c.display.PrintInfo(fmt.Sprintf("🔄 Mana regenerates +%d every second", c.gameState.Rules.ManaRegenRate(c.gameState.Phase)))
+		c.showPhase()
 		c.display.PrintInfo("🚀 Continuous combat - no turns!")
 	}
 
@@ -318,9 +456,9 @@ func (c *Client) showDetailedGameInfo() {
 	}
 
 	for i, tower := range myTowers {
-		c.display.PrintInfo(fmt.Sprintf("%d. %s: %d/%d HP (%.1f%%)",
-			i+1, tower.Name, tower.HP, tower.MaxHP,
-			float64(tower.HP)/float64(tower.MaxHP)*100))
+		c.display.PrintInfo(fmt.Sprintf("%d. %s%s: %d/%d HP (%.1f%%)%s",
+			i+1, tower.Name, laneTag(game.LaneOf(tower.Name)), tower.HP, tower.MaxHP,
+			float64(tower.HP)/float64(tower.MaxHP)*100, effectTags(tower.Effects)))
 	}
 
 	// Show opponent towers
@@ -333,9 +471,30 @@ func (c *Client) showDetailedGameInfo() {
 	}
 
 	for i, tower := range opponentTowers {
-		c.display.PrintInfo(fmt.Sprintf("%d. %s: %d/%d HP (%.1f%%)",
-			i+1, tower.Name, tower.HP, tower.MaxHP,
-			float64(tower.HP)/float64(tower.MaxHP)*100))
+		c.display.PrintInfo(fmt.Sprintf("%d. %s%s: %d/%d HP (%.1f%%)%s",
+			i+1, tower.Name, laneTag(game.LaneOf(tower.Name)), tower.HP, tower.MaxHP,
+			float64(tower.HP)/float64(tower.MaxHP)*100, effectTags(tower.Effects)))
+	}
+
+	// Show opponent troops that can be attacked
+	c.display.PrintInfo("\n=== Opponent Troops On The Field ===")
+	var opponentTroops []game.Troop
+	if c.gameState.Player1.ID == c.clientID {
+		opponentTroops = c.gameState.Player2.Troops
+	} else {
+		opponentTroops = c.gameState.Player1.Troops
+	}
+
+	onField := 0
+	for _, troop := range opponentTroops {
+		if troop.Deployed && troop.HP > 0 {
+			onField++
+			c.display.PrintInfo(fmt.Sprintf("%d. %s%s (HP: %d/%d, ATK: %d, DEF: %d)%s",
+				onField, troop.Name, laneTag(troop.Lane), troop.HP, troop.MaxHP, troop.ATK, troop.DEF, effectTags(troop.Effects)))
+		}
+	}
+	if onField == 0 {
+		c.display.PrintInfo("None")
 	}
 
 	// Show troops with deployment status
@@ -344,14 +503,18 @@ func (c *Client) showDetailedGameInfo() {
 		status := ""
 
 		if c.gameState.GameMode == game.ModeEnhanced {
-			if troop.HP > 0 || troop.Name == game.Queen {
+			if troop.Deployed && troop.HP > 0 && troop.Travel > 0 {
+				status = fmt.Sprintf(" [ON THE WAY - %.1fs]", float64(troop.Travel)/game.TickRate)
+			} else if troop.Deployed && troop.HP > 0 {
+				status = " [ON FIELD]"
+			} else if troop.HP > 0 || troop.IsSupport() {
 				status = fmt.Sprintf(" [ALIVE - Cost: %d MANA]", troop.MANA)
 			} else {
 				status = " [DESTROYED]"
 			}
 		} else if c.gameState.GameMode == game.ModeSimple {
 			troopName := string(troop.Name)
-			if troop.HP <= 0 && troop.Name != game.Queen {
+			if troop.HP <= 0 && !troop.IsSupport() {
 				status = " [DESTROYED]"
 			} else if c.deployedTroops[troopName] {
 				status = " [DEPLOYED"
@@ -365,8 +528,30 @@ func (c *Client) showDetailedGameInfo() {
 			}
 		}
 
-		c.display.PrintInfo(fmt.Sprintf("%d. %s%s (HP: %d, ATK: %d, DEF: %d, CRIT: %.0f%%)",
-			i+1, troop.Name, status, troop.HP, troop.ATK, troop.DEF, troop.CRIT*100))
+		c.display.PrintInfo(fmt.Sprintf("%d. %s%s%s (HP: %d, ATK: %d, DEF: %d, CRIT: %.0f%%)%s",
+			i+1, troop.Name, status, laneTag(troop.Lane), troop.HP, troop.ATK, troop.DEF, troop.CRIT*100, effectTags(troop.Effects)))
+	}
+
+	c.showHand()
+
+	// Show spells, they can be cast at any time and never enter the field
+	c.display.PrintInfo("\n=== Your Spells ===")
+	var mySpells []game.Spell
+	if c.gameState.Player1.ID == c.clientID {
+		mySpells = c.gameState.Player1.Spells
+	} else {
+		mySpells = c.gameState.Player2.Spells
+	}
+
+	for i, spell := range mySpells {
+		cost := ""
+		if c.gameState.GameMode == game.ModeEnhanced {
+			cost = fmt.Sprintf(" [Cost: %d MANA]", spell.MANA)
+		}
+		c.display.PrintInfo(fmt.Sprintf("%d. %s%s - %s", i+1, spell.Name, cost, spell.Description))
+	}
+	if len(mySpells) == 0 {
+		c.display.PrintInfo("None")
 	}
 
 	c.display.PrintInfo(fmt.Sprintf("\nTowers Destroyed - You: %d | Opponent: %d",
@@ -409,6 +594,9 @@ func (c *Client) debugGameState() {
 	c.display.PrintInfo(fmt.Sprintf("  Deployed Troops: %v", c.deployedTroops))
 	c.display.PrintInfo(fmt.Sprintf("  Deployed This Turn: %v", c.deployedThisTurn))
 	c.display.PrintInfo(fmt.Sprintf("  Attack Counts: %v", c.troopAttackCount))
+	if c.gameState.Rules.ActionPoints > 0 {
+		c.display.PrintInfo(fmt.Sprintf("  Action Points: %d/%d", c.actionPointsLeft(), c.gameState.Rules.ActionPoints))
+	}
 
 	availableAttackers := 0
 	for troopName, isDeployed := range c.deployedTroops {
@@ -428,13 +616,13 @@ func (c *Client) debugGameState() {
 	if c.gameState.CurrentTurn != c.clientID {
 		c.display.PrintInfo("  - Wait for your turn")
 	} else {
-		if len(c.deployedThisTurn) < 1 {
-			c.display.PrintInfo("  - You can deploy 1 more troop this turn")
+		if left := c.gameState.Rules.CardsPerTurn - len(c.deployedThisTurn); left > 0 {
+			c.display.PrintInfo(fmt.Sprintf("  - You can deploy %d more troop(s) this turn", left))
 		}
 		if availableAttackers > 0 {
 			c.display.PrintInfo(fmt.Sprintf("  - You have %d troops that can attack", availableAttackers))
 		}
-		if len(c.deployedThisTurn) >= 1 && availableAttackers == 0 {
+		if len(c.deployedThisTurn) >= c.gameState.Rules.CardsPerTurn && availableAttackers == 0 {
 			c.display.PrintInfo("  - You can end your turn")
 		}
 	}
@@ -678,10 +866,13 @@ func (c *Client) runMainLoop() error {
 		c.display.PrintInfo("")
 		c.display.PrintInfo("1. Find Match (Simple TCR)")
 		c.display.PrintInfo("2. Find Match (Enhanced TCR)")
-		c.display.PrintInfo("3. View Profile")
-		c.display.PrintInfo("4. Quit")
+		c.display.PrintInfo("3. Find Match (Correspondence)")
+		c.display.PrintInfo("4. Correspondence Games")
+		c.display.PrintInfo("5. Manage Decks")
+		c.display.PrintInfo("6. View Profile")
+		c.display.PrintInfo("7. Quit")
 
-		choice := c.input.GetMenuChoice(1, 4)
+		choice := c.input.GetMenuChoice(1, 7)
 
 		switch choice {
 		case 1:
@@ -689,14 +880,99 @@ func (c *Client) runMainLoop() error {
 		case 2:
 			c.findMatch(game.ModeEnhanced)
 		case 3:
-			c.showProfile()
+			c.findMatch(game.QueueCorrespondence)
 		case 4:
+			c.openCorrespondenceGames()
+		case 5:
+			c.manageDecks()
+		case 6:
+			c.showProfile()
+		case 7:
 			c.display.PrintInfo("Thanks for playing!")
 			return nil
 		}
 	}
 }
 
+// manageDecks lets the player build decks and pick the one used in matches
+func (c *Client) manageDecks() {
+	c.display.PrintSeparator()
+	c.display.PrintInfo("🃏 YOUR DECKS 🃏")
+	if len(c.player.Decks) == 0 {
+		c.display.PrintInfo("No decks yet, matches use random troops")
+	}
+	for i, deck := range c.player.Decks {
+		marker := ""
+		if deck.Name == c.player.ActiveDeck {
+			marker = " ⭐ ACTIVE"
+		}
+		c.display.PrintInfo(fmt.Sprintf("%d. %s %v%s", i+1, deck.Name, deck.Cards, marker))
+	}
+
+	c.display.PrintInfo("")
+	c.display.PrintInfo("1. Build New Deck")
+	c.display.PrintInfo("2. Choose Active Deck")
+	c.display.PrintInfo("3. Use Random Troops")
+	c.display.PrintInfo("4. Back")
+
+	var msg *network.Message
+	switch c.input.GetMenuChoice(1, 4) {
+	case 1:
+		if len(c.player.OwnedTroops()) < c.deckSize {
+			c.display.PrintWarning(fmt.Sprintf("You need at least %d troops to build a deck", c.deckSize))
+			return
+		}
+		name := c.input.GetStringInput(fmt.Sprintf("Deck name (1-%d characters): ", game.MaxDeckNameLength), 1, game.MaxDeckNameLength)
+		cards := c.input.GetDeckCards(c.player.OwnedTroops(), c.player.TroopLevels, c.deckSize)
+		if err := c.sendMessage(network.CreateSaveDeckMessage(c.clientID, game.Deck{Name: name, Cards: cards})); err != nil {
+			c.display.PrintError(fmt.Sprintf("Failed to save deck: %v", err))
+			return
+		}
+		if c.input.GetConfirmation("Use this deck in your next matches?") {
+			msg = network.CreateSelectDeckMessage(c.clientID, name)
+		}
+	case 2:
+		if len(c.player.Decks) == 0 {
+			c.display.PrintWarning("Build a deck first")
+			return
+		}
+		c.display.PrintInfo("Choose your active deck:")
+		for i, deck := range c.player.Decks {
+			c.display.PrintInfo(fmt.Sprintf("%d. %s %v", i+1, deck.Name, deck.Cards))
+		}
+		deck := c.player.Decks[c.input.GetMenuChoice(1, len(c.player.Decks))-1]
+		msg = network.CreateSelectDeckMessage(c.clientID, deck.Name)
+	case 3:
+		msg = network.CreateSelectDeckMessage(c.clientID, "")
+	}
+
+	if msg != nil {
+		if err := c.sendMessage(msg); err != nil {
+			c.display.PrintError(fmt.Sprintf("Failed to select deck: %v", err))
+		}
+	}
+
+	// Give the server a moment to confirm before the menu is redrawn
+	time.Sleep(300 * time.Millisecond)
+}
+
+// handleDeckUpdated stores the player data the server sends back after a deck change
+func (c *Client) handleDeckUpdated(msg *network.Message) error {
+	deckData, ok := msg.Data["deck_updated"].(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("invalid deck update format")
+	}
+
+	playerDataJson, _ := json.Marshal(deckData["player_data"])
+	if err := json.Unmarshal(playerDataJson, &c.player); err != nil {
+		return fmt.Errorf("failed to parse player data: %w", err)
+	}
+
+	message, _ := deckData["message"].(string)
+	c.display.PrintInfo("🃏 " + message)
+	return nil
+}
+
 func (c *Client) resetGameTracking() {
 	c.deployedTroops = make(map[string]bool)
 	c.troopAttackCount = make(map[string]int)
@@ -737,6 +1013,10 @@ func (c *Client) handleGameplay() error {
 		}
 
 		// Simple mode handling (existing logic)
+		if c.gameState.CurrentTurn != c.clientID && c.isCorrespondence() {
+			// No need to wait around, the opponent may take hours
+			return c.leaveCorrespondenceGame()
+		}
 		if c.gameState.CurrentTurn != c.clientID {
 			opponentName := c.getPlayerName(c.gameState.CurrentTurn)
 			waitingMsg := fmt.Sprintf("⏳ Waiting for %s's turn...", opponentName)
@@ -756,12 +1036,14 @@ func (c *Client) handleGameplay() error {
 			return nil
 		}
 
-		action := c.input.GetGameActionWithDebug(c.gameState.GameMode)
+		action := c.input.GetGameActionWithDebug(c.gameState.GameMode, c.isCorrespondence())
 
 		var err error
 		switch action {
 		case "play":
 			err = c.handlePlayCard()
+		case "spell":
+			err = c.handleCastSpell()
 		case "attack":
 			err = c.handleAttack()
 		case "info":
@@ -781,6 +1063,8 @@ func (c *Client) handleGameplay() error {
 			if err == nil {
 				return nil
 			}
+		case "leave":
+			return c.leaveCorrespondenceGame()
 		default:
 			c.display.PrintWarning("Invalid action")
 			continue
@@ -812,11 +1096,12 @@ func (c *Client) handleEnhancedGameplay() error {
 
 		c.display.PrintInfo("\n--- What do you want to do? ---")
 		c.display.PrintInfo("1. Deploy Troop")
-		c.display.PrintInfo("2. View Detailed Info")
-		c.display.PrintInfo("3. Surrender")
-		c.display.PrintInfo("4. Wait 10 seconds")
+		c.display.PrintInfo("2. Cast Spell")
+		c.display.PrintInfo("3. View Detailed Info")
+		c.display.PrintInfo("4. Surrender")
+		c.display.PrintInfo("5. Wait 10 seconds")
 
-		choice := c.input.GetMenuChoice(1, 4)
+		choice := c.input.GetMenuChoice(1, 5)
 
 		if !c.isInGame || c.gameState == nil {
 			c.display.PrintInfo("🎮 Game ended during input. Returning to main menu...")
@@ -834,12 +1119,16 @@ func (c *Client) handleEnhancedGameplay() error {
 				c.display.PrintInfo("📋 Returning to action menu...")
 			}
 		case 2:
-			c.showDetailedGameInfo()
+			if err := c.handleCastSpell(); err != nil {
+				c.display.PrintError(fmt.Sprintf("Failed: %v", err))
+			}
 		case 3:
+			c.showDetailedGameInfo()
+		case 4:
 			if err := c.handleSurrender(); err == nil {
 				return nil
 			}
-		case 4:
+		case 5:
 			c.display.PrintInfo("⏳ Observing combat for 10 seconds...")
 			c.display.PrintInfo("💡 (Combat is already happening automatically)")
 			c.display.PrintSeparator()
@@ -954,8 +1243,8 @@ func (c *Client) showCombatDetails() {
 	for _, troop := range c.myTroops {
 		if troop.HP > 0 {
 			aliveTroops++
-			c.display.PrintInfo(fmt.Sprintf("  ⚔️  %s: %d/%d HP (ATK: %d) - FIGHTING",
-				troop.Name, troop.HP, troop.MaxHP, troop.ATK))
+			c.display.PrintInfo(fmt.Sprintf("  ⚔️  %s%s: %d/%d HP (ATK: %d) - FIGHTING",
+				troop.Name, laneTag(troop.Lane), troop.HP, troop.MaxHP, troop.ATK))
 		} else {
 			c.display.PrintInfo(fmt.Sprintf("  💀 %s: DESTROYED", troop.Name))
 		}
@@ -992,19 +1281,21 @@ func (c *Client) showCombatDetails() {
 		}
 	}
 
-	// Show targeting priority
+	// Show targeting priority per lane
 	c.display.PrintInfo("🎯 Current Target Priority:")
-	guardTowersAlive := 0
-	for _, tower := range opponentTowers {
-		if (tower.Name == game.GuardTower1 || tower.Name == game.GuardTower2) && tower.HP > 0 {
-			guardTowersAlive++
+	for _, lane := range game.Lanes {
+		guardAlive := false
+		for _, tower := range opponentTowers {
+			if tower.Name == lane.GuardTower() && tower.HP > 0 {
+				guardAlive = true
+			}
 		}
-	}
 
-	if guardTowersAlive > 0 {
-		c.display.PrintInfo("  → Attacking Guard Towers first")
-	} else {
-		c.display.PrintInfo("  → Attacking King Tower (Guard Towers destroyed)")
+		if guardAlive {
+			c.display.PrintInfo(fmt.Sprintf("  → %s lane: enemy troops, then %s", lane, lane.GuardTower()))
+		} else {
+			c.display.PrintInfo(fmt.Sprintf("  → %s lane: enemy troops, then King Tower (lane open)", lane))
+		}
 	}
 
 	c.display.PrintSeparator()
@@ -1028,7 +1319,8 @@ func (c *Client) showEnhancedModeStatus() {
 	targetInfo := c.getCurrentTargetInfo()
 
 	c.display.PrintInfo(fmt.Sprintf("⚡ Mana: %d/%d | ⏰ Time: %d:%02d | 🎯 Target: %s",
-		myMana, game.MaxMana, minutes, seconds, targetInfo))
+		myMana, c.gameState.Rules.MaxMana, minutes, seconds, targetInfo))
+	c.showPhase()
 
 	c.display.PrintInfo(fmt.Sprintf("🏰 Towers Destroyed: You: %d vs Opponent: %d",
 		c.gameState.TowersKilled.Player2, c.gameState.TowersKilled.Player1))
@@ -1071,7 +1363,7 @@ func (c *Client) getCurrentTargetInfo() string {
 		if guardTowersAlive == 2 {
 			return "Guard Towers (both alive)"
 		} else {
-			return fmt.Sprintf("%s (last guard)", guardTowerNames[0])
+			return fmt.Sprintf("%s or King Tower (one lane open)", guardTowerNames[0])
 		}
 	} else {
 		// Check if King Tower is alive
@@ -1090,14 +1382,21 @@ func (c *Client) handleAttack() error {
 	var availableTroops []game.Troop
 
 	if c.gameState.GameMode == game.ModeSimple {
+		actionPoints := c.gameState.Rules.ActionPoints > 0
+		if actionPoints && c.actionPointsLeft() < game.AttackActionCost {
+			c.display.PrintWarning("Not enough action points left to attack this turn.")
+			return nil
+		}
+
 		for _, troop := range c.myTroops {
 			troopName := string(troop.Name)
 			// Check if troop is deployed and alive
 			if c.deployedTroops[troopName] && troop.HP > 0 {
 				// Allow attack if:
-				// 1. Troop hasn't attacked this turn OR
-				// 2. Troop destroyed a tower in its last attack AND it wasn't the King Tower
-				if c.troopAttackCount[troopName] < 1 || (c.troopDestroyedTower[troopName] && !c.troopDestroyedKingTower[troopName]) {
+				// 1. Action points are on, a troop attacks as often as they last OR
+				// 2. Troop hasn't attacked this turn OR
+				// 3. Troop destroyed a tower in its last attack AND it wasn't the King Tower
+				if actionPoints || c.troopAttackCount[troopName] < 1 || (c.troopDestroyedTower[troopName] && !c.troopDestroyedKingTower[troopName]) {
 					availableTroops = append(availableTroops, troop)
 				}
 			}
@@ -1109,23 +1408,26 @@ func (c *Client) handleAttack() error {
 		}
 	} else {
 		for _, troop := range c.myTroops {
-			if troop.HP > 0 {
+			if troop.HP > 0 && troop.Deployed {
 				availableTroops = append(availableTroops, troop)
 			}
 		}
 
 		if len(availableTroops) == 0 {
-			c.display.PrintWarning("No troops available for attack")
+			c.display.PrintWarning("No troops on the field. Deploy a troop in a lane first.")
 			return nil
 		}
 	}
 
 	// Get enemy towers and filter alive ones
 	var enemyTowers []game.Tower
+	var enemyTroops []game.Troop
 	if c.gameState.Player1.ID == c.clientID {
 		enemyTowers = c.gameState.Player2.Towers
+		enemyTroops = c.gameState.Player2.Troops
 	} else {
 		enemyTowers = c.gameState.Player1.Towers
+		enemyTroops = c.gameState.Player1.Troops
 	}
 
 	var aliveTowers []game.Tower
@@ -1140,14 +1442,19 @@ func (c *Client) handleAttack() error {
 		return nil
 	}
 
-	attackerIndex, targetType, targetIndex, err := c.input.GetAttackChoice(availableTroops, aliveTowers, c.gameState.GameMode)
+	attackerIndex, targetType, targetIndex, err := c.input.GetAttackChoice(availableTroops, aliveTowers, enemyTroops, c.gameState.GameMode)
 	if err != nil {
 		c.display.PrintWarning(err.Error())
 		return nil
 	}
 
 	selectedTroop := availableTroops[attackerIndex]
-	targetTower := aliveTowers[targetIndex]
+	targetName := ""
+	if targetType == "troop" {
+		targetName = string(enemyTroops[targetIndex].Name)
+	} else {
+		targetName = string(aliveTowers[targetIndex].Name)
+	}
 
 	if selectedTroop.HP <= 0 {
 		c.display.PrintError(fmt.Sprintf("%s is destroyed (HP: %d) and cannot attack", selectedTroop.Name, selectedTroop.HP))
@@ -1161,7 +1468,7 @@ func (c *Client) handleAttack() error {
 	// The server will set it back to true if the tower is destroyed
 	c.troopDestroyedTower[troopName] = false
 
-	msg := network.CreateAttackMessage(c.clientID, c.gameState.ID, selectedTroop.Name, targetType, string(targetTower.Name))
+	msg := network.CreateAttackMessage(c.clientID, c.gameState.ID, selectedTroop.Name, targetType, targetName)
 	return c.sendMessage(msg)
 }
 
@@ -1171,11 +1478,8 @@ func (c *Client) handlePlayCard() error {
 		return nil
 	}
 
-	if c.gameState.GameMode == game.ModeSimple {
-		if len(c.deployedThisTurn) >= 1 {
-			c.display.PrintError("Cannot deploy more than one troop per turn in simple mode")
-			return nil
-		}
+	if !c.checkCardBudget() {
+		return nil
 	}
 
 	var currentMana int = 999
@@ -1187,7 +1491,12 @@ func (c *Client) handlePlayCard() error {
 		}
 	}
 
-	troopIndex, err := c.input.GetTroopChoice(c.myTroops, currentMana, c.gameState.GameMode)
+	me := c.gameState.Player1
+	if c.gameState.Player2.ID == c.clientID {
+		me = c.gameState.Player2
+	}
+
+	troopIndex, err := c.input.GetTroopChoice(c.myTroops, me.Hand, me.NextCard, currentMana, c.gameState.GameMode)
 	if err != nil {
 		c.display.PrintWarning(err.Error())
 		return nil
@@ -1196,8 +1505,20 @@ func (c *Client) handlePlayCard() error {
 	selectedTroop := c.myTroops[troopIndex]
 	troopName := string(selectedTroop.Name)
 
+	// Support troops act from behind the towers, everything else picks a lane
+	var lane game.Lane
+	if !selectedTroop.IsSupport() {
+		var enemyTowers []game.Tower
+		if c.gameState.Player1.ID == c.clientID {
+			enemyTowers = c.gameState.Player2.Towers
+		} else {
+			enemyTowers = c.gameState.Player1.Towers
+		}
+		lane = c.input.GetLaneChoice(enemyTowers)
+	}
+
 	// If troop is destroyed, respawn it with full HP
-	if selectedTroop.HP <= 0 && selectedTroop.Name != game.Queen {
+	if selectedTroop.HP <= 0 && !selectedTroop.IsSupport() {
 		// Calculate full HP (same formula as server)
 		level := selectedTroop.Level
 		if level == 0 {
@@ -1256,7 +1577,51 @@ func (c *Client) handlePlayCard() error {
 		c.display.PrintInfo(fmt.Sprintf("💰 Mana spent: %d (Remaining: %d)", selectedTroop.MANA, remainingMana))
 	}
 
-	msg := network.CreateSummonMessage(c.clientID, c.gameState.ID, selectedTroop.Name)
+	msg := network.CreateSummonMessage(c.clientID, c.gameState.ID, selectedTroop.Name, lane)
+	return c.sendMessage(msg)
+}
+
+// handleCastSpell picks a spell and its target and sends the cast to the server
+func (c *Client) handleCastSpell() error {
+	var me, opponent game.Player
+	if c.gameState.Player1.ID == c.clientID {
+		me, opponent = c.gameState.Player1, c.gameState.Player2
+	} else {
+		me, opponent = c.gameState.Player2, c.gameState.Player1
+	}
+
+	if !c.checkCardBudget() {
+		return nil
+	}
+
+	actionPoints := -1
+	if c.gameState.Rules.ActionPoints > 0 {
+		actionPoints = c.actionPointsLeft()
+	}
+
+	spellIndex, err := c.input.GetSpellChoice(me.Spells, me.Mana, actionPoints, c.gameState.GameMode)
+	if err != nil {
+		c.display.PrintWarning(err.Error())
+		return nil
+	}
+	spell := me.Spells[spellIndex]
+
+	var target game.TowerType
+	if spell.Target == game.SpellTargetEnemyTower {
+		target, err = c.input.GetSpellTargetChoice(opponent.Towers)
+		if err != nil {
+			c.display.PrintWarning(err.Error())
+			return nil
+		}
+	}
+
+	if c.gameState.GameMode == game.ModeSimple {
+		c.deployedThisTurn = append(c.deployedThisTurn, string(spell.Name))
+	} else {
+		c.display.PrintInfo(fmt.Sprintf("💰 Mana spent: %d (Remaining: %d)", spell.MANA, me.Mana-spell.MANA))
+	}
+
+	msg := network.CreateCastSpellMessage(c.clientID, c.gameState.ID, spell.Name, string(target))
 	return c.sendMessage(msg)
 }
 
@@ -1309,6 +1674,9 @@ func (c *Client) messageHandler() {
 			if c.isConnected {
 				c.logger.Error("Lost connection to server")
 				c.display.PrintError("Lost connection to server")
+				if c.isInGame && !c.isCorrespondence() {
+					c.display.PrintInfo("🔌 Log back in soon to rejoin your match before it is forfeited")
+				}
 			}
 			break
 		}
@@ -1335,8 +1703,14 @@ func (c *Client) processServerMessage(data []byte) error {
 		return c.handleAuthSuccess(msg)
 	case network.MsgAuthFail:
 		return c.handleAuthFail(msg)
+	case network.MsgDeckUpdated:
+		return c.handleDeckUpdated(msg)
 	case network.MsgMatchFound:
 		return c.handleMatchFound(msg)
+	case network.MsgListGames:
+		return c.handleGameList(msg)
+	case network.MsgLeaveGame:
+		c.logger.Debug("Left correspondence game %s", msg.GameID)
 	case network.MsgGameStart:
 		return c.handleGameStart(msg)
 	case network.MsgGameEvent:
@@ -1352,6 +1726,10 @@ func (c *Client) processServerMessage(data []byte) error {
 		return c.handleManaUpdateMessage(msg)
 	case "PLAYER_DISCONNECT":
 		return c.handlePlayerDisconnectMessage(msg)
+	case network.MsgPlayerAway:
+		return c.handlePlayerAway(msg)
+	case network.MsgPlayerBack:
+		return c.handlePlayerBack(msg)
 	default:
 		c.logger.Debug("🤷 Unhandled message type: %s with data: %+v", msg.Type, msg.Data) // ✅ ADD: Show unhandled
 	}
@@ -1407,7 +1785,7 @@ func (c *Client) handleManaUpdate(msg *network.Message) error {
 		// Only show mana update every 10 seconds to avoid spam
 		if c.gameState.TimeLeft%10 == 0 {
 			c.display.PrintInfo(fmt.Sprintf("⚡ Mana: %d/%d | Time: %ds",
-				myMana, game.MaxMana, c.gameState.TimeLeft))
+				myMana, c.gameState.Rules.MaxMana, c.gameState.TimeLeft))
 		}
 	}
 
@@ -1422,6 +1800,13 @@ func (c *Client) handleAuthSuccess(msg *network.Message) error {
 	}
 
 	c.clientID = msg.PlayerID
+	if msg.GameID != "" {
+		// We dropped out of a match and are back in it, the menu waits for its GAME_START
+		c.waitingForMatch = true
+	}
+	if deckSize, ok := authResp["deck_size"].(float64); ok {
+		c.deckSize = int(deckSize)
+	}
 
 	playerDataJson, _ := json.Marshal(authResp["player_data"])
 	if err := json.Unmarshal(playerDataJson, &c.player); err != nil {
@@ -1480,11 +1865,31 @@ func (c *Client) handleGameStart(msg *network.Message) error {
 	// ✅ RESET: Initialize tracking variables
 	c.resetGameTracking()
 
+	// A resumed correspondence game already has troops on the field
+	for _, troop := range c.myTroops {
+		if troop.Deployed && troop.HP > 0 {
+			c.deployedTroops[string(troop.Name)] = true
+		}
+	}
+
 	c.isInGame = true
 	c.waitingForMatch = false
 
 	if c.gameState.GameMode == game.ModeEnhanced {
 		c.startRealTimeTimer()
+	} else {
+		c.startTurnTimer()
+	}
+
+	if resumed, _ := gameStartData["resumed"].(bool); resumed {
+		c.display.PrintGameMode(c.gameState.GameMode)
+		if c.isCorrespondence() {
+			c.display.PrintInfo(fmt.Sprintf("✉️ Correspondence game against %s resumed", c.getPlayerName(c.getOpponentID())))
+		} else {
+			c.display.PrintInfo(fmt.Sprintf("🔌 Reconnected to your match against %s", c.getPlayerName(c.getOpponentID())))
+		}
+		c.logger.Info("Game %s resumed", c.gameState.ID)
+		return nil
 	}
 
 	c.display.PrintGameStart(3, c.gameState.GameMode)
@@ -1565,6 +1970,10 @@ func (c *Client) syncLocalTroopsFromGameState() {
 				c.myTroops[i].MaxHP = serverTroops[j].MaxHP
 				c.myTroops[i].ATK = serverTroops[j].ATK
 				c.myTroops[i].DEF = serverTroops[j].DEF
+				c.myTroops[i].Deployed = serverTroops[j].Deployed
+				c.myTroops[i].Lane = serverTroops[j].Lane
+				c.myTroops[i].Travel = serverTroops[j].Travel
+				c.myTroops[i].Effects = serverTroops[j].Effects
 
 				if oldHP != c.myTroops[i].HP {
 					c.logger.Debug("🔄 Troop %s HP synced: %d -> %d",
@@ -1583,15 +1992,25 @@ func (c *Client) displayGameEvent(event game.CombatAction) {
 	case game.ActionSummon:
 		playerName := c.getPlayerName(event.PlayerID)
 		troopName := string(event.TroopName)
-		c.display.PrintTroopSummoned(playerName, troopName, isMyAction)
+		lane, _ := event.Data["lane"].(string)
+		travel, _ := event.Data["travel_seconds"].(float64)
+		c.display.PrintTroopSummoned(playerName, troopName, game.Lane(lane), travel, isMyAction)
+
+	case game.EventTroopArrived:
+		lane, _ := event.Data["lane"].(string)
+		c.display.PrintTroopArrived(c.getPlayerName(event.PlayerID), string(event.TroopName), game.Lane(lane), isMyAction)
+
+	case game.ActionCastSpell:
+		spellName, _ := event.Data["spell"].(string)
+		c.display.PrintSpellCast(c.getPlayerName(event.PlayerID), spellName, event.TargetName, isMyAction)
 
 	case game.ActionAttack:
 		attacker := string(event.TroopName)
 		target := event.TargetName
 
-		isCounter := false
-		if data, ok := event.Data["is_counter"]; ok {
-			isCounter, _ = data.(bool)
+		isTowerShot := false
+		if data, ok := event.Data["tower_shot"]; ok {
+			isTowerShot, _ = data.(bool)
 		}
 
 		currentHP := 0
@@ -1599,8 +2018,8 @@ func (c *Client) displayGameEvent(event game.CombatAction) {
 			currentHP = int(targetHP)
 		}
 
-		if isCounter {
-			c.display.PrintCounterAttack(attacker, target, event.Damage)
+		if isTowerShot {
+			c.display.PrintTowerShot(attacker, target, event.Damage, event.IsCrit)
 		} else {
 			c.display.PrintAttack(attacker, target, event.Damage, event.IsCrit)
 		}
@@ -1612,6 +2031,15 @@ func (c *Client) displayGameEvent(event game.CombatAction) {
 		target := event.TargetName
 		c.display.PrintHeal(healer, target, event.HealAmount)
 
+	case game.ActionAbility:
+		message, _ := event.Data["message"].(string)
+		c.display.PrintAbility(string(event.TroopName), message, isMyAction)
+
+	case game.ActionEffectApplied, game.ActionEffectRemoved:
+		message, _ := event.Data["message"].(string)
+		ownerID, _ := event.Data["owner_id"].(string)
+		c.display.PrintEffect(message, event.Type == game.ActionEffectApplied, ownerID == c.clientID)
+
 	case "TOWER_DESTROYED":
 		destroyer := event.Data["destroyer"].(string)
 		owner := event.Data["owner"].(string)
@@ -1641,6 +2069,17 @@ func (c *Client) displayGameEvent(event game.CombatAction) {
 		isMyDestruction := event.PlayerID == c.clientID
 		c.display.PrintTroopDestroyed(destroyer, troopName, owner, isMyDestruction)
 
+	case game.EventDoubleMana, game.EventOvertime:
+		phase, _ := event.Data["phase"].(string)
+		timeLeft, _ := event.Data["time_left"].(float64)
+		manaRegen, _ := event.Data["mana_regen"].(float64)
+		c.display.PrintPhaseChange(phase, int(timeLeft), int(manaRegen))
+
+	case game.EventKingActivated:
+		owner, _ := event.Data["owner"].(string)
+		reason, _ := event.Data["reason"].(string)
+		c.display.PrintKingActivated(owner, reason, event.PlayerID == c.clientID)
+
 	case "TROOP_REVIVED":
 		troopName := string(event.TroopName)
 		if event.PlayerID == c.clientID {
@@ -1669,34 +2108,111 @@ func (c *Client) startRealTimeTimer() {
 	go func() {
 		ticker := time.NewTicker(1 * time.Second)
 		defer ticker.Stop()
+		timesUpShown := false
 
 		for c.isInGame && c.gameState != nil && c.gameState.GameMode == game.ModeEnhanced {
 			select {
 			case <-ticker.C:
 				if c.gameState.TimeLeft > 0 {
 					c.gameState.TimeLeft--
+					timesUpShown = false
 
 					// Update mana locally (will be synced by server)
+					regen := c.gameState.Rules.ManaRegenRate(c.gameState.Phase)
 					if c.gameState.Player1.ID == c.clientID {
-						if c.gameState.Player1.Mana < game.MaxMana {
-							c.gameState.Player1.Mana++
-						}
+						c.gameState.Player1.Mana = min(c.gameState.Player1.Mana+regen, c.gameState.Rules.MaxMana)
 					} else {
-						if c.gameState.Player2.Mana < game.MaxMana {
-							c.gameState.Player2.Mana++
-						}
+						c.gameState.Player2.Mana = min(c.gameState.Player2.Mana+regen, c.gameState.Rules.MaxMana)
 					}
 				}
 
-				if c.gameState.TimeLeft <= 0 {
+				c.countDownTransit()
+
+				// Keep ticking, a tie at timeout goes to overtime and the server resets the clock
+				if c.gameState.TimeLeft <= 0 && !timesUpShown {
 					c.display.PrintInfo("⏰ TIME'S UP! Waiting for server to determine winner...")
-					return
+					timesUpShown = true
 				}
 			}
 		}
 	}()
 }
 
+// startTurnTimer counts the Simple mode turn clock and chess clock down
+// locally and warns the player to move when either is nearly out (will be
+// synced by server)
+func (c *Client) startTurnTimer() {
+	rules := c.gameState.Rules
+	if c.gameState.GameMode != game.ModeSimple || (rules.TurnSeconds <= 0 && rules.BankSeconds <= 0) {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(1 * time.Second)
+		defer ticker.Stop()
+
+		for c.isInGame && c.gameState != nil && c.gameState.GameMode == game.ModeSimple {
+			<-ticker.C
+			if c.gameState.Paused {
+				continue // The server holds the clocks until the opponent is back
+			}
+			c.countDownTimeBank()
+			if c.gameState.TurnTimeLeft <= 0 {
+				continue
+			}
+
+			c.gameState.TurnTimeLeft--
+			if c.gameState.CurrentTurn != c.clientID {
+				continue
+			}
+			if left := c.gameState.TurnTimeLeft; left == 10 || left == 5 {
+				c.display.PrintTurnClockWarning(left)
+			}
+		}
+	}()
+}
+
+// countDownTimeBank runs the chess clock of the player to move down a second
+// and warns when it is ours and nearly out (Simple mode)
+func (c *Client) countDownTimeBank() {
+	mover := &c.gameState.Player1
+	if c.gameState.Player2.ID == c.gameState.CurrentTurn {
+		mover = &c.gameState.Player2
+	}
+	if mover.TimeBank <= 0 {
+		return
+	}
+
+	mover.TimeBank--
+	if mover.ID == c.clientID && (mover.TimeBank == 30 || mover.TimeBank == 10) {
+		c.display.PrintTimeBankWarning(mover.TimeBank)
+	}
+}
+
+// countDownTransit ticks down the units still walking their lane and shows
+// how long each needs to reach the fight (will be synced by server)
+func (c *Client) countDownTransit() {
+	gameState := c.gameState
+	if gameState == nil {
+		return
+	}
+
+	mine := gameState.Player1.ID == c.clientID
+	for side, units := range [][]game.Unit{gameState.Units.Player1, gameState.Units.Player2} {
+		for i := range units {
+			unit := &units[i]
+			if unit.ArrivesIn <= 0 {
+				continue
+			}
+
+			unit.ArrivesIn = max(unit.ArrivesIn-1, 0)
+			if secondsLeft := int(math.Ceil(unit.ArrivesIn)); secondsLeft > 0 {
+				c.display.PrintTroopTransit(string(unit.Name), unit.Lane, secondsLeft, mine == (side == 0))
+			}
+		}
+	}
+}
+
 // getOpponentID returns the ID of the opponent player
 func (c *Client) getOpponentID() string {
 	if c.gameState == nil {
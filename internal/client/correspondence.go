@@ -0,0 +1,96 @@
+// Package client handles correspondence games: listing the player's open
+// games, picking one back up and leaving it until their next turn
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"tcr-game/internal/network"
+)
+
+// openCorrespondenceGames lists the player's open correspondence games and
+// picks one back up
+func (c *Client) openCorrespondenceGames() {
+	if err := c.sendMessage(network.NewMessage(network.MsgListGames, c.clientID, "")); err != nil {
+		c.display.PrintError(fmt.Sprintf("Failed to list games: %v", err))
+		return
+	}
+
+	var openGames []network.CorrespondenceGameInfo
+	select {
+	case openGames = <-c.gameList:
+	case <-time.After(5 * time.Second):
+		c.display.PrintError("Server did not send your games, please try again")
+		return
+	}
+
+	c.display.PrintSeparator()
+	c.display.PrintInfo("✉️ CORRESPONDENCE GAMES ✉️")
+	if len(openGames) == 0 {
+		c.display.PrintInfo("No open games, find a correspondence match to start one")
+		return
+	}
+
+	for i, info := range openGames {
+		status := "⏳ their turn"
+		if info.YourTurn {
+			status = "🔥 YOUR TURN"
+		}
+		c.display.PrintInfo(fmt.Sprintf("%d. vs %s (%s) - %s, move by %s",
+			i+1, info.Opponent, info.Ruleset, status, info.Deadline.Local().Format("Mon 15:04")))
+	}
+	c.display.PrintInfo(fmt.Sprintf("%d. Back", len(openGames)+1))
+
+	choice := c.input.GetMenuChoice(1, len(openGames)+1)
+	if choice > len(openGames) {
+		return
+	}
+
+	info := openGames[choice-1]
+	if err := c.sendMessage(network.CreateOpenGameMessage(c.clientID, info.GameID)); err != nil {
+		c.display.PrintError(fmt.Sprintf("Failed to open game: %v", err))
+		return
+	}
+
+	c.display.PrintInfo(fmt.Sprintf("Opening your game against %s...", info.Opponent))
+	if info.YourTurn {
+		c.display.PrintInfo(fmt.Sprintf("⏰ Make your move before %s", info.Deadline.Local().Format("Mon Jan 2 15:04")))
+	}
+	c.waitingForMatch = true // The menu waits for GAME_START
+}
+
+// handleGameList passes the server's list of open games to the menu
+func (c *Client) handleGameList(msg *network.Message) error {
+	var openGames []network.CorrespondenceGameInfo
+	gamesJson, _ := json.Marshal(msg.Data["open_games"])
+	if err := json.Unmarshal(gamesJson, &openGames); err != nil {
+		return fmt.Errorf("failed to parse open games: %w", err)
+	}
+
+	select {
+	case c.gameList <- openGames:
+	default:
+		// Nobody is waiting for it any more
+	}
+	return nil
+}
+
+// leaveCorrespondenceGame goes back to the menu, the game stays open on the
+// server until the player picks it up again
+func (c *Client) leaveCorrespondenceGame() error {
+	msg := network.NewMessage(network.MsgLeaveGame, c.clientID, c.gameState.ID)
+	if err := c.sendMessage(msg); err != nil {
+		return err
+	}
+
+	c.display.PrintInfo("✉️ Game saved. Find it under Correspondence Games when it's your turn.")
+	c.isInGame = false
+	return nil
+}
+
+// isCorrespondence reports whether the current game is played by correspondence
+func (c *Client) isCorrespondence() bool {
+	return c.gameState != nil && c.gameState.Rules.Correspondence()
+}
@@ -93,7 +93,7 @@ func (d *Display) PrintGameStart(countdown int, gameMode string) {
 }
 
 // PrintCardPlayed displays when a troop is summoned
-func (d *Display) PrintTroopSummoned(player string, troopName string, isPlayer bool) {
+func (d *Display) PrintTroopSummoned(player string, troopName string, lane game.Lane, travelSeconds float64, isPlayer bool) {
 	timestamp := time.Now().Format("15:04:05")
 	var colorFunc *color.Color
 	if isPlayer {
@@ -102,8 +102,59 @@ func (d *Display) PrintTroopSummoned(player string, troopName string, isPlayer b
 		colorFunc = d.enemyColor
 	}
 
-	colorFunc.Printf("[%s] [TURN LOG] %s summoned %s\n",
-		timestamp, player, troopName)
+	where := ""
+	if lane != "" {
+		where = fmt.Sprintf(" in the %s lane", lane)
+	}
+	if travelSeconds > 0 {
+		where += fmt.Sprintf(", arriving in %.1fs", travelSeconds)
+	}
+
+	colorFunc.Printf("[%s] [TURN LOG] %s summoned %s%s\n",
+		timestamp, player, troopName, where)
+}
+
+// PrintTroopTransit counts down a troop still walking its lane to the fight
+func (d *Display) PrintTroopTransit(troopName string, lane game.Lane, secondsLeft int, isPlayer bool) {
+	if isPlayer {
+		d.playerColor.Printf("   🚶 Your %s reaches the %s lane fight in %ds\n", troopName, lane, secondsLeft)
+	} else {
+		d.enemyColor.Printf("   🚶 Enemy %s reaches the %s lane fight in %ds\n", troopName, lane, secondsLeft)
+	}
+}
+
+// PrintTroopArrived displays a troop reaching the fight at the end of its lane
+func (d *Display) PrintTroopArrived(player string, troopName string, lane game.Lane, isPlayer bool) {
+	timestamp := time.Now().Format("15:04:05")
+	var colorFunc *color.Color
+	if isPlayer {
+		colorFunc = d.playerColor
+	} else {
+		colorFunc = d.enemyColor
+	}
+
+	colorFunc.Printf("[%s] [🏁 ARRIVED] %s's %s reached the fight in the %s lane\n",
+		timestamp, player, troopName, lane)
+}
+
+// PrintSpellCast displays when a spell is cast, target is empty for
+// spells that act on the caster's own troops
+func (d *Display) PrintSpellCast(player string, spellName string, target string, isPlayer bool) {
+	timestamp := time.Now().Format("15:04:05")
+	var colorFunc *color.Color
+	if isPlayer {
+		colorFunc = d.playerColor
+	} else {
+		colorFunc = d.enemyColor
+	}
+
+	on := ""
+	if target != "" {
+		on = fmt.Sprintf(" on %s", target)
+	}
+
+	colorFunc.Printf("[%s] [🪄 SPELL] %s cast %s%s\n",
+		timestamp, player, spellName, on)
 }
 
 // PrintAttack displays attack events with detailed damage info
@@ -111,7 +162,7 @@ func (d *Display) PrintAttack(attacker, target string, damage int, isCrit bool)
 	timestamp := time.Now().Format("15:04:05")
 
 	if isCrit {
-		d.critColor.Printf("[%s] [💥 CRITICAL HIT!] %s → %s: -%d HP (1.5x damage!)\n",
+		d.critColor.Printf("[%s] [💥 CRITICAL HIT!] %s → %s: -%d HP (critical damage!)\n",
 			timestamp, attacker, target, damage)
 	} else {
 		d.attackColor.Printf("[%s] [⚔️  ATTACK] %s → %s: -%d HP\n",
@@ -119,10 +170,16 @@ func (d *Display) PrintAttack(attacker, target string, damage int, isCrit bool)
 	}
 }
 
-func (d *Display) PrintCounterAttack(attacker, target string, damage int) {
+// PrintTowerShot displays a tower firing at a troop
+func (d *Display) PrintTowerShot(tower, target string, damage int, isCrit bool) {
 	timestamp := time.Now().Format("15:04:05")
-	d.warningColor.Printf("[%s] [🛡️  COUNTER-ATTACK] %s counter-attacks %s for %d damage!\n",
-		timestamp, attacker, target, damage)
+
+	crit := ""
+	if isCrit {
+		crit = " (critical damage!)"
+	}
+	d.warningColor.Printf("[%s] [🏰 TOWER FIRE] %s fires at %s for %d damage!%s\n",
+		timestamp, tower, target, damage, crit)
 }
 
 // PrintHeal displays healing events
@@ -132,6 +189,37 @@ func (d *Display) PrintHeal(healer, target string, amount int) {
 		timestamp, healer, target, amount)
 }
 
+// PrintAbility displays a troop ability taking effect
+func (d *Display) PrintAbility(troopName, message string, isPlayer bool) {
+	timestamp := time.Now().Format("15:04:05")
+	var colorFunc *color.Color
+	if isPlayer {
+		colorFunc = d.playerColor
+	} else {
+		colorFunc = d.enemyColor
+	}
+
+	colorFunc.Printf("[%s] [✨ ABILITY] %s: %s\n", timestamp, troopName, message)
+}
+
+// PrintEffect displays a status effect being applied to or removed from a
+// troop or tower. isPlayer is true when the affected unit is the player's.
+func (d *Display) PrintEffect(message string, applied bool, isPlayer bool) {
+	timestamp := time.Now().Format("15:04:05")
+	var colorFunc *color.Color
+	if isPlayer {
+		colorFunc = d.playerColor
+	} else {
+		colorFunc = d.enemyColor
+	}
+
+	label := "EFFECT"
+	if !applied {
+		label = "EFFECT ENDED"
+	}
+	colorFunc.Printf("[%s] [🌀 %s] %s\n", timestamp, label, message)
+}
+
 func (d *Display) PrintGameEnd(winner string, isPlayerWinner bool, towersDestroyed map[string]int) {
 	d.infoColor.Println("\n[GAME ENDED]")
 
@@ -212,8 +300,8 @@ func (d *Display) PrintTowerStatus(towers []game.Tower, playerName string) {
 			healthColor = d.attackColor // Red for critical
 		}
 
-		healthColor.Printf("%s: %d/%d HP (%.1f%%)\n",
-			tower.Name, tower.HP, tower.MaxHP, healthPercent)
+		healthColor.Printf("%s%s%s: %d/%d HP (%.1f%%)%s\n",
+			tower.Name, laneTag(game.LaneOf(tower.Name)), dormantTag(tower), tower.HP, tower.MaxHP, healthPercent, effectTags(tower.Effects))
 	}
 }
 
@@ -224,24 +312,68 @@ func (d *Display) PrintTroops(troops []game.Troop) {
 		status := ""
 		if troop.HP <= 0 {
 			status = " [DESTROYED]"
-		} else if troop.Name == game.Queen {
-			status = " [HEALER]"
+		} else if troop.IsSupport() {
+			status = " [SUPPORT]"
 		} else {
 			status = " [CAN ATTACK]"
 		}
 
-		d.playerColor.Printf("%d. %s%s (HP: %d, ATK: %d, DEF: %d) - %s\n",
-			i+1, troop.Name, status, troop.HP, troop.ATK, troop.DEF, troop.Special)
+		d.playerColor.Printf("%d. %s%s%s (HP: %d, ATK: %d, DEF: %d)%s - %s\n",
+			i+1, troop.Name, status, laneTag(troop.Lane), troop.HP, troop.ATK, troop.DEF, effectTags(troop.Effects), troop.Special)
 	}
 }
 
+// PrintBattlefield displays the units each side has on the field, lane by lane
+func (d *Display) PrintBattlefield(myUnits, opponentUnits []game.Unit) {
+	d.infoColor.Println("\n=== Battlefield ===")
+	for _, lane := range game.Lanes {
+		d.infoColor.Printf("[%s LANE]\n", strings.ToUpper(string(lane)))
+		d.playerColor.Printf("  You:      %s\n", unitList(myUnits, lane))
+		d.enemyColor.Printf("  Opponent: %s\n", unitList(opponentUnits, lane))
+	}
+}
+
+// unitList lists the units in a lane with their HP, or "-" when it is empty
+func unitList(units []game.Unit, lane game.Lane) string {
+	var names []string
+	for _, unit := range units {
+		if unit.Lane != lane {
+			continue
+		}
+		if unit.ArrivesIn > 0 {
+			names = append(names, fmt.Sprintf("%s (%d/%d HP, 🚶 arrives in %.1fs)", unit.Name, unit.HP, unit.MaxHP, unit.ArrivesIn))
+		} else {
+			names = append(names, fmt.Sprintf("%s (%d/%d HP)", unit.Name, unit.HP, unit.MaxHP))
+		}
+	}
+	if len(names) == 0 {
+		return "-"
+	}
+	return strings.Join(names, ", ")
+}
+
+// PrintHand displays the cards in hand and the next card to be drawn
+func (d *Display) PrintHand(hand []game.TroopType, nextCard game.TroopType) {
+	cards := make([]string, len(hand))
+	for i, card := range hand {
+		cards[i] = string(card)
+	}
+
+	next := ""
+	if nextCard != "" {
+		next = fmt.Sprintf(" | ⏭  Next: %s", nextCard)
+	}
+
+	d.playerColor.Printf("🃏 Hand: %s%s\n", strings.Join(cards, ", "), next)
+}
+
 // PrintAttackOptions displays attack interface
 func (d *Display) PrintAttackOptions(troops []game.Troop, towers []game.Tower) {
 	d.infoColor.Println("\n=== ATTACK PHASE ===")
 
 	d.infoColor.Println("Your Troops:")
 	for i, troop := range troops {
-		if troop.Name != game.Queen {
+		if !troop.IsSupport() {
 			d.playerColor.Printf("%d. %s (ATK: %d)\n", i+1, troop.Name, troop.ATK)
 		}
 	}
@@ -255,6 +387,53 @@ func (d *Display) PrintAttackOptions(troops []game.Troop, towers []game.Tower) {
 	}
 }
 
+// laneTag labels a troop or tower with its lane, empty when it has none
+func laneTag(lane game.Lane) string {
+	if lane == "" {
+		return ""
+	}
+	return fmt.Sprintf(" [%s LANE]", strings.ToUpper(string(lane)))
+}
+
+// dormantTag marks a standing tower that does not fire yet, i.e. a King
+// Tower that has not been activated
+func dormantTag(tower game.Tower) string {
+	if tower.IsActive || tower.HP <= 0 {
+		return ""
+	}
+	return " [💤 DORMANT]"
+}
+
+// effectIcons marks each status effect type in troop and tower listings
+var effectIcons = map[string]string{
+	game.EffectStun:   "💫",
+	game.EffectSlow:   "🐌",
+	game.EffectPoison: "☠️",
+	game.EffectShield: "🛡️",
+	game.EffectRage:   "💢",
+}
+
+// effectTags lists active status effects with the turns or seconds they
+// have left, e.g. " {💫stun (2), 🛡️shield 150HP}"
+func effectTags(effects []game.StatusEffect) string {
+	if len(effects) == 0 {
+		return ""
+	}
+
+	tags := make([]string, 0, len(effects))
+	for _, effect := range effects {
+		tag := effectIcons[effect.Type] + effect.Type
+		if effect.Type == game.EffectShield {
+			tag += fmt.Sprintf(" %dHP", effect.Shield)
+		}
+		if effect.Remaining > 0 {
+			tag += fmt.Sprintf(" (%d)", effect.Remaining)
+		}
+		tags = append(tags, tag)
+	}
+	return " {" + strings.Join(tags, ", ") + "}"
+}
+
 // PrintError displays error messages
 func (d *Display) PrintError(message string) {
 	d.loseColor.Printf("[ERROR] %s\n", message)
@@ -280,6 +459,59 @@ func (d *Display) PrintSeparator() {
 	d.infoColor.Println("═══════════════════════════════════════════════════════════════")
 }
 
+// PrintPhaseChange announces a new Enhanced mode match phase
+func (d *Display) PrintPhaseChange(phase string, timeLeft, manaRegen int) {
+	timestamp := time.Now().Format("15:04:05")
+
+	switch phase {
+	case game.PhaseDoubleMana:
+		d.gameColor.Printf("[%s] [⚡ DOUBLE MANA] %ds left, mana now regenerates +%d every second!\n",
+			timestamp, timeLeft, manaRegen)
+	case game.PhaseOvertime:
+		d.gameColor.Printf("[%s] [🔥 OVERTIME] Towers lost are tied! Sudden death for %ds, the first tower destroyed wins!\n",
+			timestamp, timeLeft)
+	}
+}
+
+// PrintTurnTimedOut announces that a player's turn clock ran out (Simple mode)
+func (d *Display) PrintTurnTimedOut(playerName string, isMine bool) {
+	timestamp := time.Now().Format("15:04:05")
+
+	if isMine {
+		d.warningColor.Printf("[%s] [⏰ TIME OUT] Your turn clock ran out, the turn passes to your opponent!\n", timestamp)
+	} else {
+		d.gameColor.Printf("[%s] [⏰ TIME OUT] %s's turn clock ran out!\n", timestamp, playerName)
+	}
+}
+
+// PrintTurnClockWarning warns the player to move that their turn is almost over
+func (d *Display) PrintTurnClockWarning(secondsLeft int) {
+	d.warningColor.Printf("⏰ %ds left on your turn clock!\n", secondsLeft)
+}
+
+// PrintTimeBankWarning warns the player that their chess clock is nearly out
+func (d *Display) PrintTimeBankWarning(secondsLeft int) {
+	d.warningColor.Printf("⏱️ Only %ds left in your time bank, you lose the match when it runs out!\n", secondsLeft)
+}
+
+// PrintKingActivated announces that a player's King Tower woke up
+func (d *Display) PrintKingActivated(ownerName, reason string, isMine bool) {
+	timestamp := time.Now().Format("15:04:05")
+
+	cause := "it was hit"
+	if reason == game.KingActivatedGuardDestroyed {
+		cause = "a Guard Tower fell"
+	}
+
+	if isMine {
+		d.warningColor.Printf("[%s] [👑 KING AWAKE] Your King Tower activated because %s and now fires back!\n",
+			timestamp, cause)
+	} else {
+		d.gameColor.Printf("[%s] [👑 KING AWAKE] %s's King Tower activated because %s and now fires back!\n",
+			timestamp, ownerName, cause)
+	}
+}
+
 func (d *Display) PrintTowerDestroyed(destroyerName, towerName, ownerName string, isMyDestruction bool) {
 	timestamp := time.Now().Format("15:04:05")
 
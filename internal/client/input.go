@@ -85,30 +85,51 @@ func (ih *InputHandler) GetUsername() string {
 	}
 }
 
-// GetTroopChoice gets and validates troop selection from available troops
-func (ih *InputHandler) GetTroopChoice(troops []game.Troop, availableMana int, gameMode string) (int, error) {
-	if len(troops) == 0 {
+// GetTroopChoice gets and validates troop selection from the current hand.
+// hand lists the playable cards in slot order; the returned index is into troops.
+func (ih *InputHandler) GetTroopChoice(troops []game.Troop, hand []game.TroopType, nextCard game.TroopType, availableMana int, gameMode string) (int, error) {
+	// Only the cards in hand can be played, the rest of the deck waits in the queue
+	handTroops := make([]int, 0, len(hand))
+	for _, card := range hand {
+		for i, troop := range troops {
+			if troop.Name == card {
+				handTroops = append(handTroops, i)
+				break
+			}
+		}
+	}
+
+	if len(handTroops) == 0 {
 		return -1, fmt.Errorf("no troops available")
 	}
 
-	// Show available troops
-	ih.display.PrintInfo("Available troops:")
+	// Show the hand
+	ih.display.PrintInfo("Your hand:")
 	playableTroops := make([]int, 0)
 
-	for i, troop := range troops {
-		// Handle Queen specially
-		if troop.Name == game.Queen {
+	for slot, i := range handTroops {
+		troop := troops[i]
+
+		// Support troops like the Queen act through their special ability
+		if troop.IsSupport() {
 			ih.display.PrintInfo(fmt.Sprintf("%d. %s ✓ Special: %s",
-				i+1, troop.Name, troop.Special))
-			playableTroops = append(playableTroops, i)
+				slot+1, troop.Name, troop.Special))
+			playableTroops = append(playableTroops, slot)
 			continue
 		}
 
 		// For other troops, check if they're destroyed
 		if troop.HP <= 0 {
 			ih.display.PrintInfo(fmt.Sprintf("%d. %s (HP: %d, ATK: %d) ✓ Can respawn",
-				i+1, troop.Name, troop.HP, troop.ATK))
-			playableTroops = append(playableTroops, i)
+				slot+1, troop.Name, troop.HP, troop.ATK))
+			playableTroops = append(playableTroops, slot)
+			continue
+		}
+
+		// In Enhanced mode a troop stays on the field until destroyed
+		if gameMode == "enhanced" && troop.Deployed {
+			ih.display.PrintWarning(fmt.Sprintf("%d. %s%s (HP: %d/%d) ❌ Already on the field",
+				slot+1, troop.Name, laneTag(troop.Lane), troop.HP, troop.MaxHP))
 			continue
 		}
 
@@ -119,18 +140,22 @@ func (ih *InputHandler) GetTroopChoice(troops []game.Troop, availableMana int, g
 		if isPlayable {
 			if gameMode == "enhanced" {
 				ih.display.PrintInfo(fmt.Sprintf("%d. %s (Cost: %d MANA, HP: %d, ATK: %d, CRIT: %.0f%%) ✓",
-					i+1, troop.Name, troop.MANA, troop.HP, troop.ATK, troop.CRIT*100))
+					slot+1, troop.Name, troop.MANA, troop.HP, troop.ATK, troop.CRIT*100))
 			} else {
 				ih.display.PrintInfo(fmt.Sprintf("%d. %s (HP: %d, ATK: %d) ✓",
-					i+1, troop.Name, troop.HP, troop.ATK))
+					slot+1, troop.Name, troop.HP, troop.ATK))
 			}
-			playableTroops = append(playableTroops, i)
+			playableTroops = append(playableTroops, slot)
 		} else {
 			ih.display.PrintWarning(fmt.Sprintf("%d. %s (Cost: %d, HP: %d, ATK: %d) ❌ Not enough mana",
-				i+1, troop.Name, troop.MANA, troop.HP, troop.ATK))
+				slot+1, troop.Name, troop.MANA, troop.HP, troop.ATK))
 		}
 	}
 
+	if nextCard != "" {
+		ih.display.PrintInfo(fmt.Sprintf("⏭  Next card: %s", nextCard))
+	}
+
 	if len(playableTroops) == 0 {
 		if gameMode == "enhanced" {
 			return -1, fmt.Errorf("no playable troops with current mana (%d)", availableMana)
@@ -141,12 +166,12 @@ func (ih *InputHandler) GetTroopChoice(troops []game.Troop, availableMana int, g
 
 	// Get choice
 	for {
-		choice := ih.GetMenuChoice(1, len(troops)) - 1 // Convert to 0-based index
+		choice := ih.GetMenuChoice(1, len(handTroops)) - 1 // Convert to 0-based slot
 
 		// Check if troop is playable
 		isPlayable := false
-		for _, playableIndex := range playableTroops {
-			if choice == playableIndex {
+		for _, playableSlot := range playableTroops {
+			if choice == playableSlot {
 				isPlayable = true
 				break
 			}
@@ -161,8 +186,108 @@ func (ih *InputHandler) GetTroopChoice(troops []game.Troop, availableMana int, g
 			continue
 		}
 
-		return choice, nil
+		return handTroops[choice], nil
+	}
+}
+
+// GetSpellChoice gets and validates spell selection. actionPoints is the
+// budget left this turn, or -1 when the ruleset has none (Simple mode).
+func (ih *InputHandler) GetSpellChoice(spells []game.Spell, availableMana int, actionPoints int, gameMode string) (int, error) {
+	if len(spells) == 0 {
+		return -1, fmt.Errorf("no spells available")
+	}
+
+	ih.display.PrintInfo("Available spells:")
+	playableSpells := make(map[int]bool)
+
+	for i, spell := range spells {
+		if gameMode == game.ModeSimple {
+			// The server only takes the spells Simple mode can use, paid in action points when the ruleset has them
+			if spell.EnhancedOnly {
+				ih.display.PrintWarning(fmt.Sprintf("%d. %s ❌ Enhanced mode only", i+1, spell.Name))
+			} else if actionPoints < 0 {
+				ih.display.PrintInfo(fmt.Sprintf("%d. %s ✓ %s", i+1, spell.Name, spell.Description))
+				playableSpells[i] = true
+			} else if spell.MANA <= actionPoints {
+				ih.display.PrintInfo(fmt.Sprintf("%d. %s (Cost: %d AP) ✓ %s", i+1, spell.Name, spell.MANA, spell.Description))
+				playableSpells[i] = true
+			} else {
+				ih.display.PrintWarning(fmt.Sprintf("%d. %s (Cost: %d AP) ❌ Not enough action points", i+1, spell.Name, spell.MANA))
+			}
+		} else if spell.MANA <= availableMana {
+			ih.display.PrintInfo(fmt.Sprintf("%d. %s (Cost: %d MANA) ✓ %s", i+1, spell.Name, spell.MANA, spell.Description))
+			playableSpells[i] = true
+		} else {
+			ih.display.PrintWarning(fmt.Sprintf("%d. %s (Cost: %d MANA) ❌ Not enough mana", i+1, spell.Name, spell.MANA))
+		}
+	}
+
+	if len(playableSpells) == 0 {
+		if gameMode == game.ModeSimple {
+			return -1, fmt.Errorf("no spells you can cast this turn")
+		}
+		return -1, fmt.Errorf("no playable spells with current mana (%d)", availableMana)
+	}
+
+	for {
+		choice := ih.GetMenuChoice(1, len(spells)) - 1
+		if playableSpells[choice] {
+			return choice, nil
+		}
+		if gameMode == game.ModeSimple {
+			ih.display.PrintWarning("You can't cast that spell this turn. Choose another spell.")
+		} else {
+			ih.display.PrintWarning("Not enough mana to cast that spell. Choose another spell.")
+		}
+	}
+}
+
+// GetSpellTargetChoice asks which standing enemy tower a spell is cast on
+func (ih *InputHandler) GetSpellTargetChoice(enemyTowers []game.Tower) (game.TowerType, error) {
+	var aliveTowers []game.Tower
+	for _, tower := range enemyTowers {
+		if tower.HP > 0 {
+			aliveTowers = append(aliveTowers, tower)
+		}
+	}
+
+	if len(aliveTowers) == 0 {
+		return "", fmt.Errorf("no enemy towers left to target")
+	}
+
+	ih.display.PrintInfo("Choose a target tower:")
+	for i, tower := range aliveTowers {
+		ih.display.PrintInfo(fmt.Sprintf("%d. %s (HP: %d/%d)", i+1, tower.Name, tower.HP, tower.MaxHP))
+	}
+
+	return aliveTowers[ih.GetMenuChoice(1, len(aliveTowers))-1].Name, nil
+}
+
+// GetDeckCards asks for size different troops out of the ones the player owns
+func (ih *InputHandler) GetDeckCards(owned []game.TroopType, levels map[game.TroopType]int, size int) []game.TroopType {
+	cards := make([]game.TroopType, 0, size)
+	picked := make(map[game.TroopType]bool)
+
+	for len(cards) < size {
+		ih.display.PrintInfo(fmt.Sprintf("Pick card %d of %d:", len(cards)+1, size))
+		for i, troop := range owned {
+			status := ""
+			if picked[troop] {
+				status = " ✓ In deck"
+			}
+			ih.display.PrintInfo(fmt.Sprintf("%d. %s (Level %d)%s", i+1, troop, levels[troop], status))
+		}
+
+		choice := owned[ih.GetMenuChoice(1, len(owned))-1]
+		if picked[choice] {
+			ih.display.PrintWarning(fmt.Sprintf("%s is already in the deck", choice))
+			continue
+		}
+		picked[choice] = true
+		cards = append(cards, choice)
 	}
+
+	return cards
 }
 
 // GetConfirmation gets yes/no confirmation from user
@@ -301,16 +426,35 @@ func (ih *InputHandler) GetGameAction(gameMode string) string {
 	}
 }
 
-// GetAttackChoice lets player choose attacker and target
-func (ih *InputHandler) GetAttackChoice(myTroops []game.Troop, enemyTowers []game.Tower, gameMode string) (attackerIndex int, targetType string, targetIndex int, err error) {
+// GetLaneChoice asks which lane to deploy a troop in, showing the enemy
+// Guard Tower defending each lane
+func (ih *InputHandler) GetLaneChoice(enemyTowers []game.Tower) game.Lane {
+	ih.display.PrintInfo("Choose a lane:")
+	for i, lane := range game.Lanes {
+		defender := "open, King Tower exposed"
+		for _, tower := range enemyTowers {
+			if tower.Name == lane.GuardTower() && tower.HP > 0 {
+				defender = fmt.Sprintf("%s HP: %d/%d", tower.Name, tower.HP, tower.MaxHP)
+			}
+		}
+		ih.display.PrintInfo(fmt.Sprintf("%d. %s lane (%s)", i+1, lane, defender))
+	}
+
+	return game.Lanes[ih.GetMenuChoice(1, len(game.Lanes))-1]
+}
+
+// GetAttackChoice lets player choose attacker and target.
+// Targets are the enemy towers followed by the enemy troops on the field;
+// targetIndex indexes enemyTowers or enemyTroops depending on targetType.
+func (ih *InputHandler) GetAttackChoice(myTroops []game.Troop, enemyTowers []game.Tower, enemyTroops []game.Troop, gameMode string) (attackerIndex int, targetType string, targetIndex int, err error) {
 	// Show available attackers
 	ih.display.PrintInfo("Choose your attacker:")
 	availableAttackers := make([]int, 0)
 
 	for i, troop := range myTroops {
-		// Skip Queen as it can't attack and dead troops
-		if troop.Name != game.Queen && troop.HP > 0 {
-			ih.display.PrintInfo(fmt.Sprintf("%d. %s (ATK: %d)", i+1, troop.Name, troop.ATK))
+		// Skip support troops (they can't attack), dead troops and troops not in a lane
+		if !troop.IsSupport() && troop.HP > 0 && troop.Lane != "" {
+			ih.display.PrintInfo(fmt.Sprintf("%d. %s%s (ATK: %d)", i+1, troop.Name, laneTag(troop.Lane), troop.ATK))
 			availableAttackers = append(availableAttackers, i)
 		}
 	}
@@ -341,28 +485,30 @@ func (ih *InputHandler) GetAttackChoice(myTroops []game.Troop, enemyTowers []gam
 		break
 	}
 
-	// Show available targets
-	ih.display.PrintInfo("Choose your target:")
+	// Show available targets, the attacker can only reach its own lane
+	lane := myTroops[attackerIndex].Lane
+	ih.display.PrintInfo(fmt.Sprintf("Choose your target in the %s lane:", lane))
 	availableTargets := make([]int, 0)
 
+	laneOpen := true
+	for _, tower := range enemyTowers {
+		if tower.Name == lane.GuardTower() && tower.HP > 0 {
+			laneOpen = false
+		}
+	}
+
 	for i, tower := range enemyTowers {
 		if tower.HP > 0 {
-			// Simple mode: enforce targeting rules
-			if gameMode == "simple" && tower.Name == game.KingTower {
-				// Check if Guard Towers are still alive
-				guardTowersAlive := false
-				for _, t := range enemyTowers {
-					if (t.Name == game.GuardTower1 || t.Name == game.GuardTower2) && t.HP > 0 {
-						guardTowersAlive = true
-						break
-					}
-				}
-
-				if guardTowersAlive {
-					ih.display.PrintWarning(fmt.Sprintf("%d. %s (HP: %d/%d) ❌ Must destroy Guard Towers first",
-						i+1, tower.Name, tower.HP, tower.MaxHP))
-					continue
-				}
+			if tower.Name == game.KingTower && !laneOpen {
+				ih.display.PrintWarning(fmt.Sprintf("%d. %s (HP: %d/%d) ❌ Must destroy %s first",
+					i+1, tower.Name, tower.HP, tower.MaxHP, lane.GuardTower()))
+				continue
+			}
+
+			if towerLane := game.LaneOf(tower.Name); towerLane != "" && towerLane != lane {
+				ih.display.PrintWarning(fmt.Sprintf("%d. %s%s (HP: %d/%d) ❌ Other lane",
+					i+1, tower.Name, laneTag(towerLane), tower.HP, tower.MaxHP))
+				continue
 			}
 
 			ih.display.PrintInfo(fmt.Sprintf("%d. %s (HP: %d/%d)",
@@ -371,13 +517,22 @@ func (ih *InputHandler) GetAttackChoice(myTroops []game.Troop, enemyTowers []gam
 		}
 	}
 
+	// Enemy troops in the same lane are numbered after the towers
+	for i, troop := range enemyTroops {
+		if troop.Deployed && troop.HP > 0 && !troop.IsSupport() && troop.Lane == lane {
+			ih.display.PrintInfo(fmt.Sprintf("%d. 🗡️  %s (HP: %d/%d, DEF: %d)",
+				len(enemyTowers)+i+1, troop.Name, troop.HP, troop.MaxHP, troop.DEF))
+			availableTargets = append(availableTargets, len(enemyTowers)+i)
+		}
+	}
+
 	if len(availableTargets) == 0 {
 		return -1, "", -1, fmt.Errorf("no valid targets available")
 	}
 
 	// Get target choice
 	for {
-		targetChoice := ih.GetMenuChoice(1, len(enemyTowers)) - 1
+		targetChoice := ih.GetMenuChoice(1, len(enemyTowers)+len(enemyTroops)) - 1
 
 		// Check if valid target
 		isValidTarget := false
@@ -393,8 +548,13 @@ func (ih *InputHandler) GetAttackChoice(myTroops []game.Troop, enemyTowers []gam
 			continue
 		}
 
-		targetIndex = targetChoice
-		targetType = "tower"
+		if targetChoice < len(enemyTowers) {
+			targetIndex = targetChoice
+			targetType = "tower"
+		} else {
+			targetIndex = targetChoice - len(enemyTowers)
+			targetType = "troop"
+		}
 		break
 	}
 
@@ -431,25 +591,32 @@ func (ih *InputHandler) ClearInputBuffer() {
 	ih.scanner = bufio.NewScanner(os.Stdin)
 }
 
-func (ih *InputHandler) GetGameActionWithDebug(gameMode string) string {
+func (ih *InputHandler) GetGameActionWithDebug(gameMode string, canLeave bool) string {
 	for {
 		ih.display.PrintInfo("\n=== GAME ACTIONS ===")
 		ih.display.PrintInfo("play - Deploy a troop")
+		ih.display.PrintInfo("spell - Cast a spell")
 		ih.display.PrintInfo("attack - Attack with troop")
 		ih.display.PrintInfo("info - Show detailed game info")
 		ih.display.PrintInfo("debug - Show debug information")
 		if gameMode == game.ModeSimple {
 			ih.display.PrintInfo("end - End your turn")
 		}
+		if canLeave {
+			ih.display.PrintInfo("leave - Save the game and come back later")
+		}
 		ih.display.PrintInfo("surrender - Give up")
 
 		action := ih.GetStringInput("Enter your command: ", 1, 20)
 		action = strings.ToLower(strings.TrimSpace(action))
 
-		validActions := []string{"play", "attack", "info", "debug", "surrender"}
+		validActions := []string{"play", "spell", "attack", "info", "debug", "surrender"}
 		if gameMode == game.ModeSimple {
 			validActions = append(validActions, "end")
 		}
+		if canLeave {
+			validActions = append(validActions, "leave")
+		}
 
 		for _, valid := range validActions {
 			if action == valid {
@@ -0,0 +1,51 @@
+// Package client handles an opponent dropping out of the match and coming
+// back within the server's reconnect grace period
+package client
+
+import (
+	"fmt"
+
+	"tcr-game/internal/network"
+)
+
+// handlePlayerAway tells the player their opponent lost connection and how
+// long the server waits for them
+func (c *Client) handlePlayerAway(msg *network.Message) error {
+	awayData, ok := msg.Data["player_away"].(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("invalid player away format")
+	}
+
+	player, _ := awayData["player"].(string)
+	graceSeconds, _ := awayData["grace_seconds"].(float64)
+	paused, _ := awayData["paused"].(bool)
+
+	c.display.PrintWarning(fmt.Sprintf("🔌 %s lost connection, they have %d seconds to come back or forfeit", player, int(graceSeconds)))
+	if paused {
+		c.display.PrintInfo("⏸️ The match is paused until they return")
+	}
+	if c.gameState != nil {
+		c.gameState.Paused = paused
+	}
+	return nil
+}
+
+// handlePlayerBack tells the player their opponent is back in the match
+func (c *Client) handlePlayerBack(msg *network.Message) error {
+	backData, ok := msg.Data["player_back"].(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("invalid player back format")
+	}
+
+	player, _ := backData["player"].(string)
+	paused, _ := backData["paused"].(bool)
+
+	c.display.PrintInfo(fmt.Sprintf("🔌 %s is back", player))
+	if c.gameState != nil && c.gameState.Paused && !paused {
+		c.display.PrintInfo("▶️ The match carries on")
+	}
+	if c.gameState != nil {
+		c.gameState.Paused = paused
+	}
+	return nil
+}
@@ -0,0 +1,375 @@
+// Package client handles terminal playback of recorded matches
+package client
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"tcr-game/internal/game"
+)
+
+// replayFrame is how often the replay player advances playback
+const replayFrame = 100 * time.Millisecond
+
+// ReplayPlayer plays back the events of a re-simulated match
+type ReplayPlayer struct {
+	display  *Display
+	header   game.ReplayHeader
+	events   []game.ReplayEntry
+	final    *game.GameState
+	povID    string // Player whose perspective is rendered
+	speed    float64
+	paused   bool
+	cursor   int           // Next event to render
+	position time.Duration // Current playback position in match time
+	ended    bool          // GAME_END already shown, the engine may emit more than one
+}
+
+// NewReplayPlayer creates a player for a re-simulated match.
+// pov selects whose perspective is shown: 1 or 2.
+func NewReplayPlayer(replay *game.Replay, pov int, speed float64) *ReplayPlayer {
+	povID := replay.Header.InitialState.Player1.ID
+	if pov == 2 {
+		povID = replay.Header.InitialState.Player2.ID
+	}
+
+	if speed <= 0 {
+		speed = 1
+	}
+
+	return &ReplayPlayer{
+		display: NewDisplay(),
+		header:  replay.Header,
+		events:  replay.Events,
+		final:   replay.FinalState,
+		povID:   povID,
+		speed:   speed,
+	}
+}
+
+// Play runs the interactive playback loop until the user quits
+func (rp *ReplayPlayer) Play(start time.Duration, paused bool) {
+	rp.paused = paused
+	rp.printHeader()
+	rp.printHelp()
+	rp.jumpTo(start)
+
+	commands := make(chan string)
+	go func() {
+		scanner := bufio.NewScanner(os.Stdin)
+		for scanner.Scan() {
+			commands <- strings.ToLower(strings.TrimSpace(scanner.Text()))
+		}
+		close(commands)
+	}()
+
+	ticker := time.NewTicker(replayFrame)
+	defer ticker.Stop()
+
+	finished := false
+	for {
+		select {
+		case cmd, ok := <-commands:
+			if !ok || !rp.handleCommand(cmd) {
+				return
+			}
+			finished = false
+		case <-ticker.C:
+			if rp.paused || finished {
+				continue
+			}
+
+			rp.position += time.Duration(float64(replayFrame) * rp.speed)
+			rp.renderUntil(rp.position)
+
+			if rp.cursor >= len(rp.events) {
+				finished = true
+				rp.printEnd()
+			}
+		}
+	}
+}
+
+// handleCommand applies a playback command, returns false to quit
+func (rp *ReplayPlayer) handleCommand(cmd string) bool {
+	fields := strings.Fields(cmd)
+	if len(fields) == 0 {
+		fields = []string{"p"} // Enter toggles pause
+	}
+
+	switch fields[0] {
+	case "p", "pause", "play":
+		rp.paused = !rp.paused
+		if rp.paused {
+			rp.display.PrintInfo(fmt.Sprintf("⏸  Paused at %s", formatMatchTime(rp.position)))
+		} else {
+			rp.display.PrintInfo(fmt.Sprintf("▶  Playing at %.2gx", rp.speed))
+		}
+	case "s", "step":
+		rp.paused = true
+		rp.step()
+	case "+":
+		rp.setSpeed(rp.speed * 2)
+	case "-":
+		rp.setSpeed(rp.speed / 2)
+	case "x", "speed":
+		if len(fields) < 2 {
+			rp.display.PrintWarning("Usage: x <multiplier>")
+			break
+		}
+		speed, err := strconv.ParseFloat(fields[1], 64)
+		if err != nil || speed <= 0 {
+			rp.display.PrintWarning("Speed must be a positive number")
+			break
+		}
+		rp.setSpeed(speed)
+	case "j", "jump":
+		if len(fields) < 2 {
+			rp.display.PrintWarning("Usage: j <seconds> or j <m:ss>")
+			break
+		}
+		target, err := ParseMatchTime(fields[1])
+		if err != nil {
+			rp.display.PrintWarning(err.Error())
+			break
+		}
+		rp.jumpTo(target)
+	case "h", "help":
+		rp.printHelp()
+	case "q", "quit":
+		return false
+	default:
+		rp.display.PrintWarning(fmt.Sprintf("Unknown command %q (h for help)", cmd))
+	}
+
+	return true
+}
+
+// step renders the next event and moves the playback position to it
+func (rp *ReplayPlayer) step() {
+	if rp.cursor >= len(rp.events) {
+		rp.printEnd()
+		return
+	}
+
+	rp.position = rp.eventTime(rp.cursor)
+	rp.renderUntil(rp.position)
+}
+
+// jumpTo moves playback to target, skipping the events before it
+func (rp *ReplayPlayer) jumpTo(target time.Duration) {
+	if target < 0 {
+		target = 0
+	}
+
+	rp.cursor = 0
+	rp.ended = false
+	for rp.cursor < len(rp.events) && rp.eventTime(rp.cursor) < target {
+		rp.cursor++
+	}
+	rp.position = target
+
+	if target > 0 {
+		rp.display.PrintSeparator()
+		rp.display.PrintInfo(fmt.Sprintf("⏩ Jumped to %s", formatMatchTime(target)))
+	}
+}
+
+func (rp *ReplayPlayer) setSpeed(speed float64) {
+	rp.speed = speed
+	rp.display.PrintInfo(fmt.Sprintf("⚡ Speed %.2gx", rp.speed))
+}
+
+// renderUntil prints every event up to and including the given match time
+func (rp *ReplayPlayer) renderUntil(position time.Duration) {
+	for rp.cursor < len(rp.events) && rp.eventTime(rp.cursor) <= position {
+		rp.renderEvent(rp.events[rp.cursor])
+		rp.cursor++
+	}
+}
+
+// eventTime is the match time of an event. An untimed Simple match has no
+// clock, so its events are spaced one second apart.
+func (rp *ReplayPlayer) eventTime(index int) time.Duration {
+	if rp.header.GameMode == game.ModeEnhanced || rp.header.InitialState.Rules.ClockDriven() {
+		return time.Duration(rp.events[index].Tick) * game.TickDuration
+	}
+	return time.Duration(index) * time.Second
+}
+
+func (rp *ReplayPlayer) renderEvent(entry game.ReplayEntry) {
+	event := entry.Event
+	isPOV := event.PlayerID == rp.povID
+
+	switch event.Type {
+	case game.ActionSummon:
+		lane, _ := event.Data["lane"].(string)
+		travel, _ := event.Data["travel_seconds"].(float64)
+		rp.display.PrintTroopSummoned(rp.playerName(event.PlayerID), string(event.TroopName), game.Lane(lane), travel, isPOV)
+
+	case game.EventTroopArrived:
+		lane, _ := event.Data["lane"].(string)
+		rp.display.PrintTroopArrived(rp.playerName(event.PlayerID), string(event.TroopName), game.Lane(lane), isPOV)
+
+	case game.ActionCastSpell:
+		spellName, _ := event.Data["spell"].(string)
+		rp.display.PrintSpellCast(rp.playerName(event.PlayerID), spellName, event.TargetName, isPOV)
+
+	case game.ActionAttack:
+		isTowerShot, _ := event.Data["tower_shot"].(bool)
+		if isTowerShot {
+			rp.display.PrintTowerShot(string(event.TroopName), event.TargetName, event.Damage, event.IsCrit)
+		} else {
+			rp.display.PrintAttack(string(event.TroopName), event.TargetName, event.Damage, event.IsCrit)
+		}
+		if targetHP, ok := event.Data["target_hp"].(float64); ok {
+			rp.display.PrintInfo(fmt.Sprintf("   └─ %s now has %d HP remaining", event.TargetName, int(targetHP)))
+		}
+
+	case game.ActionHeal:
+		rp.display.PrintHeal(string(event.TroopName), event.TargetName, event.HealAmount)
+
+	case game.ActionAbility:
+		message, _ := event.Data["message"].(string)
+		rp.display.PrintAbility(string(event.TroopName), message, isPOV)
+
+	case game.ActionEffectApplied, game.ActionEffectRemoved:
+		message, _ := event.Data["message"].(string)
+		ownerID, _ := event.Data["owner_id"].(string)
+		rp.display.PrintEffect(message, event.Type == game.ActionEffectApplied, ownerID == rp.povID)
+
+	case "TOWER_DESTROYED":
+		destroyer, _ := event.Data["destroyer"].(string)
+		owner, _ := event.Data["owner"].(string)
+		rp.display.PrintTowerDestroyed(destroyer, event.TargetName, owner, isPOV)
+
+	case "TROOP_DESTROYED":
+		destroyer, _ := event.Data["destroyer"].(string)
+		owner, _ := event.Data["owner"].(string)
+		rp.display.PrintTroopDestroyed(destroyer, event.TargetName, owner, isPOV)
+
+	case "EXP_GAINED":
+		amount, _ := event.Data["amount"].(float64)
+		reason, _ := event.Data["reason"].(string)
+		rp.display.PrintEXPGain(int(amount), reason, isPOV)
+
+	case "MANA_UPDATE":
+		// Only show the clock every 10 seconds to avoid spam
+		timeLeft, _ := event.Data["time_left"].(float64)
+		if int(timeLeft)%10 == 0 {
+			p1Mana, _ := event.Data["player1_mana"].(float64)
+			p2Mana, _ := event.Data["player2_mana"].(float64)
+			rp.display.PrintInfo(fmt.Sprintf("⏰ %ds left | ⚡ %s: %d | %s: %d",
+				int(timeLeft),
+				rp.header.InitialState.Player1.Username, int(p1Mana),
+				rp.header.InitialState.Player2.Username, int(p2Mana)))
+		}
+
+	case game.EventDoubleMana, game.EventOvertime:
+		phase, _ := event.Data["phase"].(string)
+		timeLeft, _ := event.Data["time_left"].(float64)
+		manaRegen, _ := event.Data["mana_regen"].(float64)
+		rp.display.PrintPhaseChange(phase, int(timeLeft), int(manaRegen))
+
+	case game.EventKingActivated:
+		owner, _ := event.Data["owner"].(string)
+		reason, _ := event.Data["reason"].(string)
+		rp.display.PrintKingActivated(owner, reason, isPOV)
+
+	case "TURN_END":
+		nextTurn, _ := event.Data["next_turn"].(string)
+		if reason, _ := event.Data["reason"].(string); reason == game.TurnEndedByTimeout {
+			rp.display.PrintTurnTimedOut(rp.playerName(event.PlayerID), event.PlayerID == rp.povID)
+			rp.display.PrintInfo(fmt.Sprintf("🔄 %s to play", rp.playerName(nextTurn)))
+			return
+		}
+		rp.display.PrintInfo(fmt.Sprintf("🔄 %s ended the turn, %s to play",
+			rp.playerName(event.PlayerID), rp.playerName(nextTurn)))
+
+	case "GAME_END":
+		if rp.ended {
+			return
+		}
+		rp.ended = true
+		winner, _ := event.Data["winner"].(string)
+		reason, _ := event.Data["reason"].(string)
+		rp.display.PrintInfo(fmt.Sprintf("🏁 Game over (%s)", game.DescribeEndReason(reason)))
+		rp.display.PrintGameEnd(winner, winner == rp.povID, rp.towersDestroyed())
+	}
+}
+
+func (rp *ReplayPlayer) playerName(playerID string) string {
+	switch playerID {
+	case rp.header.InitialState.Player1.ID:
+		return rp.header.InitialState.Player1.Username
+	case rp.header.InitialState.Player2.ID:
+		return rp.header.InitialState.Player2.Username
+	}
+	return "Unknown"
+}
+
+// towersDestroyed maps each player to the number of enemy towers they took
+func (rp *ReplayPlayer) towersDestroyed() map[string]int {
+	if rp.final == nil {
+		return map[string]int{}
+	}
+	return map[string]int{
+		rp.final.Player1.Username: rp.final.TowersKilled.Player2,
+		rp.final.Player2.Username: rp.final.TowersKilled.Player1,
+	}
+}
+
+func (rp *ReplayPlayer) printHeader() {
+	initial := rp.header.InitialState
+
+	rp.display.PrintBanner()
+	rp.display.PrintMatchmaking(initial.Player1.Username, initial.Player2.Username)
+	rp.display.PrintGameMode(rp.header.GameMode)
+	rp.display.PrintInfo(fmt.Sprintf("Game: %s | Seed: %d | Specs: %s | Recorded: %s",
+		rp.header.GameID, rp.header.Seed, rp.header.SpecsVersion,
+		rp.header.RecordedAt.Format("2006-01-02 15:04:05")))
+	rp.display.PrintTowerStatus(initial.Player1.Towers, initial.Player1.Username)
+	rp.display.PrintTowerStatus(initial.Player2.Towers, initial.Player2.Username)
+	rp.display.PrintSeparator()
+}
+
+func (rp *ReplayPlayer) printHelp() {
+	rp.display.PrintInfo("Controls (type then Enter): [Enter]/p play-pause | s step | + / - speed | x <n> set speed | j <sec|m:ss> jump | q quit")
+}
+
+func (rp *ReplayPlayer) printEnd() {
+	rp.display.PrintSeparator()
+	rp.display.PrintInfo("📼 End of replay (j <time> to rewind, q to quit)")
+	if rp.final != nil {
+		rp.display.PrintTowerStatus(rp.final.Player1.Towers, rp.final.Player1.Username)
+		rp.display.PrintTowerStatus(rp.final.Player2.Towers, rp.final.Player2.Username)
+	}
+}
+
+// formatMatchTime renders a playback position as m:ss
+func formatMatchTime(d time.Duration) string {
+	seconds := int(d / time.Second)
+	return fmt.Sprintf("%d:%02d", seconds/60, seconds%60)
+}
+
+// ParseMatchTime parses a match time given as seconds ("90") or m:ss ("1:30")
+func ParseMatchTime(value string) (time.Duration, error) {
+	if minutes, seconds, found := strings.Cut(value, ":"); found {
+		m, errM := strconv.Atoi(minutes)
+		s, errS := strconv.Atoi(seconds)
+		if errM != nil || errS != nil || m < 0 || s < 0 || s >= 60 {
+			return 0, fmt.Errorf("invalid time %q, use seconds or m:ss", value)
+		}
+		return time.Duration(m*60+s) * time.Second, nil
+	}
+
+	seconds, err := strconv.ParseFloat(value, 64)
+	if err != nil || seconds < 0 {
+		return 0, fmt.Errorf("invalid time %q, use seconds or m:ss", value)
+	}
+	return time.Duration(seconds * float64(time.Second)), nil
+}
@@ -0,0 +1,471 @@
+// Package game implements the data-driven troop ability system
+package game
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Ability hooks. troops.json declares which hook fires each ability.
+const (
+	TriggerOnSummon = "on_summon" // Troop was summoned
+	TriggerOnAttack = "on_attack" // Troop hit an enemy tower or troop
+	TriggerOnDeath  = "on_death"  // Troop was destroyed
+)
+
+// Built-in ability types and the params they read
+const (
+	AbilityHealLowestTower = "heal_lowest_tower" // amount: HP restored to the weakest friendly tower
+	AbilitySplashDamage    = "splash_damage"     // percent: share of the hit dealt to every other enemy tower, or troop in the lane
+	AbilityShield          = "shield"            // amount, duration: shield effect on the troop, 0 duration lasts until depleted
+	AbilityManaRefund      = "mana_refund"       // amount: mana given back to the owner (Enhanced mode)
+	AbilityDamageOverTime  = "damage_over_time"  // damage, hits: poison effect on the target, one hit per step
+	AbilityStun            = "stun"              // duration: stun effect on the target
+	AbilitySlow            = "slow"              // percent, duration: slow effect lowering the target's ATK
+)
+
+// abilityContext describes the hook an ability fired from
+type abilityContext struct {
+	playerID    string // Owner of the troop
+	troop       *Troop
+	target      *Tower // Tower that was hit (on_attack only)
+	targetTroop *Troop // Troop that was hit (on_attack only)
+	damage      int    // Damage the hit dealt (on_attack only)
+}
+
+// abilityHandler applies one ability. Handlers broadcast their own actions.
+type abilityHandler func(ge *GameEngine, ability AbilitySpec, ctx abilityContext)
+
+// abilityHandlers is the ability registry, keyed by AbilitySpec.Type
+var abilityHandlers map[string]abilityHandler
+
+// Handlers can fire other abilities (e.g. splash kills trigger on-death),
+// so the registry is filled in init to avoid an initialization cycle
+func init() {
+	abilityHandlers = map[string]abilityHandler{
+		AbilityHealLowestTower: healLowestTower,
+		AbilitySplashDamage:    splashDamage,
+		AbilityShield:          grantShield,
+		AbilityManaRefund:      refundMana,
+		AbilityDamageOverTime:  damageOverTime,
+		AbilityStun:            stunTarget,
+		AbilitySlow:            slowTarget,
+	}
+}
+
+var abilityTriggers = map[string]bool{
+	TriggerOnSummon: true,
+	TriggerOnAttack: true,
+	TriggerOnDeath:  true,
+}
+
+// Param returns a numeric ability parameter, or fallback when it is not set
+func (a AbilitySpec) Param(name string, fallback float64) float64 {
+	if value, ok := a.Params[name]; ok {
+		return value
+	}
+	return fallback
+}
+
+// ValidateAbilities checks every troop ability names a registered type and hook
+func ValidateAbilities(troopSpecs map[TroopType]TroopSpec) error {
+	names := make([]TroopType, 0, len(troopSpecs))
+	for name := range troopSpecs {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return names[i] < names[j] })
+
+	for _, name := range names {
+		for _, ability := range troopSpecs[name].Abilities {
+			if _, ok := abilityHandlers[ability.Type]; !ok {
+				return fmt.Errorf("troop %s: unknown ability type %q", name, ability.Type)
+			}
+			if !abilityTriggers[ability.Trigger] {
+				return fmt.Errorf("troop %s: unknown trigger %q for ability %s", name, ability.Trigger, ability.Type)
+			}
+		}
+	}
+
+	return nil
+}
+
+// triggerAbilities runs the troop's abilities declared for the given hook
+func (ge *GameEngine) triggerAbilities(trigger string, ctx abilityContext) {
+	spec, ok := ge.gameSpecs.TroopSpecs[ctx.troop.Name]
+	if !ok {
+		return
+	}
+
+	for _, ability := range spec.Abilities {
+		if ability.Trigger != trigger {
+			continue
+		}
+		if !ge.isRunning {
+			return
+		}
+
+		handler, ok := abilityHandlers[ability.Type]
+		if !ok {
+			ge.logger.Warn("Unknown ability %s on %s, skipping", ability.Type, ctx.troop.Name)
+			continue
+		}
+		handler(ge, ability, ctx)
+	}
+}
+
+// healLowestTower heals the friendly tower with the lowest HP
+func healLowestTower(ge *GameEngine, ability AbilitySpec, ctx abilityContext) {
+	player := ge.getPlayer(ctx.playerID)
+	if player == nil {
+		return
+	}
+
+	var lowestTower *Tower
+	lowestHP := math.MaxInt32
+
+	for i := range player.Towers {
+		if player.Towers[i].HP < lowestHP && player.Towers[i].HP > 0 {
+			lowestHP = player.Towers[i].HP
+			lowestTower = &player.Towers[i]
+		}
+	}
+
+	if lowestTower == nil {
+		return
+	}
+
+	healAmount := int(ability.Param("amount", 0))
+	if lowestTower.HP+healAmount > lowestTower.MaxHP {
+		healAmount = lowestTower.MaxHP - lowestTower.HP
+	}
+
+	oldHP := lowestTower.HP
+	lowestTower.HP += healAmount
+
+	ge.logEvent("HEAL", ctx.playerID, map[string]interface{}{
+		"troop":       ctx.troop.Name,
+		"target":      lowestTower.Name,
+		"heal_amount": healAmount,
+		"tower_hp":    lowestTower.HP,
+		"old_hp":      oldHP,
+	})
+
+	ge.broadcastAction(CombatAction{
+		Type:       ActionHeal,
+		PlayerID:   ctx.playerID,
+		TroopName:  ctx.troop.Name,
+		TargetType: "tower",
+		TargetName: string(lowestTower.Name),
+		HealAmount: healAmount,
+		Timestamp:  ge.clock.Now(),
+		Data: map[string]interface{}{
+			"tower_hp":    lowestTower.HP,
+			"old_hp":      oldHP,
+			"heal_amount": healAmount,
+			"ability":     ability.Type,
+		},
+	})
+}
+
+// splashDamage spreads a share of the hit to the other enemy towers the troop
+// can reach from its lane, or to the other enemy troops in the same lane when
+// the hit landed on a troop
+func splashDamage(ge *GameEngine, ability AbilitySpec, ctx abilityContext) {
+	opponent := ge.getOpponent(ctx.playerID)
+	if opponent == nil || ctx.damage <= 0 {
+		return
+	}
+
+	splash := int(float64(ctx.damage) * ability.Param("percent", 0) / 100)
+	if splash <= 0 {
+		return
+	}
+
+	if ctx.targetTroop != nil {
+		for i := range opponent.Troops {
+			troop := &opponent.Troops[i]
+			if troop == ctx.targetTroop || !engaged(troop) || troop.Lane != ctx.targetTroop.Lane {
+				continue
+			}
+			ge.abilityDamageTroop(ctx.playerID, ctx.troop.Name, ability.Type, troop, splash)
+		}
+		return
+	}
+
+	for i := range opponent.Towers {
+		tower := &opponent.Towers[i]
+		if tower == ctx.target || tower.HP <= 0 {
+			continue
+		}
+		// Splash obeys the lane rules like the hit itself
+		if validateLaneTarget(opponent, ctx.troop, "tower", string(tower.Name)) != nil {
+			continue
+		}
+		ge.abilityDamageTower(ctx.playerID, ctx.troop.Name, ability.Type, tower, splash)
+	}
+}
+
+// grantShield gives the troop a damage-absorbing shield, replacing any left over
+func grantShield(ge *GameEngine, ability AbilitySpec, ctx abilityContext) {
+	amount := int(ability.Param("amount", 0))
+	player := ge.getPlayer(ctx.playerID)
+	if amount <= 0 || ctx.troop.IsSupport() || player == nil {
+		return
+	}
+
+	ge.applyEffect(troopCarrier(player, ctx.troop), StatusEffect{
+		Type:      EffectShield,
+		Source:    ctx.troop.Name,
+		SourceID:  ctx.playerID,
+		Shield:    amount,
+		Remaining: int(ability.Param("duration", 0)),
+	})
+}
+
+// refundMana gives mana back to the owner, capped at their max mana
+func refundMana(ge *GameEngine, ability AbilitySpec, ctx abilityContext) {
+	if ge.gameState.GameMode != ModeEnhanced {
+		return // Simple mode has no mana economy
+	}
+
+	player := ge.getPlayer(ctx.playerID)
+	if player == nil {
+		return
+	}
+
+	refund := int(ability.Param("amount", 0))
+	if player.Mana+refund > player.MaxMana {
+		refund = player.MaxMana - player.Mana
+	}
+	if refund <= 0 {
+		return
+	}
+
+	player.Mana += refund
+
+	ge.broadcastAbility(ctx, ability, fmt.Sprintf("%s refunds %d mana", ctx.troop.Name, refund), map[string]interface{}{
+		"mana_refund": refund,
+		"mana_left":   player.Mana,
+	})
+}
+
+// damageOverTime poisons the tower or troop that was hit
+func damageOverTime(ge *GameEngine, ability AbilitySpec, ctx abilityContext) {
+	damage := int(ability.Param("damage", 0))
+	hits := int(ability.Param("hits", 1))
+	if damage <= 0 || hits <= 0 {
+		return
+	}
+
+	if carrier, ok := ge.hitCarrier(ctx); ok {
+		ge.applyEffect(carrier, StatusEffect{
+			Type:      EffectPoison,
+			Source:    ctx.troop.Name,
+			SourceID:  ctx.playerID,
+			Damage:    damage,
+			Remaining: hits,
+		})
+	}
+}
+
+// stunTarget stops the tower or troop that was hit from attacking
+func stunTarget(ge *GameEngine, ability AbilitySpec, ctx abilityContext) {
+	duration := int(ability.Param("duration", 1))
+	if duration <= 0 {
+		return
+	}
+
+	if carrier, ok := ge.hitCarrier(ctx); ok {
+		ge.applyEffect(carrier, StatusEffect{
+			Type:      EffectStun,
+			Source:    ctx.troop.Name,
+			SourceID:  ctx.playerID,
+			Remaining: duration,
+		})
+	}
+}
+
+// slowTarget lowers the ATK of the tower or troop that was hit by a percentage
+func slowTarget(ge *GameEngine, ability AbilitySpec, ctx abilityContext) {
+	duration := int(ability.Param("duration", 1))
+	percent := ability.Param("percent", 0)
+	carrier, ok := ge.hitCarrier(ctx)
+	if duration <= 0 || percent <= 0 || !ok {
+		return
+	}
+
+	var baseATK int
+	if carrier.troop != nil {
+		baseATK = carrier.troop.ATK
+	} else {
+		baseATK = carrier.tower.ATK
+	}
+
+	ge.applyEffect(carrier, StatusEffect{
+		Type:      EffectSlow,
+		Source:    ctx.troop.Name,
+		SourceID:  ctx.playerID,
+		ATKMod:    -int(float64(baseATK) * percent / 100),
+		Remaining: duration,
+	})
+}
+
+// hitCarrier returns the enemy tower or troop an on_attack hook hit, if it still stands
+func (ge *GameEngine) hitCarrier(ctx abilityContext) (effectCarrier, bool) {
+	opponent := ge.getOpponent(ctx.playerID)
+	if opponent == nil {
+		return effectCarrier{}, false
+	}
+
+	if ctx.targetTroop != nil && ctx.targetTroop.HP > 0 {
+		return troopCarrier(opponent, ctx.targetTroop), true
+	}
+	if ctx.target != nil && ctx.target.HP > 0 {
+		return towerCarrier(opponent, ctx.target), true
+	}
+	return effectCarrier{}, false
+}
+
+// abilityDamageTower deals ability or poison damage to an enemy tower. It
+// ignores DEF but not shields. Handles EXP and tower destruction like a regular attack.
+func (ge *GameEngine) abilityDamageTower(playerID string, troopName TroopType, abilityType string, tower *Tower, damage int) {
+	player := ge.getPlayer(playerID)
+	opponent := ge.getOpponent(playerID)
+	if player == nil || opponent == nil {
+		return
+	}
+
+	damage, absorbed := ge.absorbWithShield(towerCarrier(opponent, tower), damage)
+
+	oldHP := tower.HP
+	tower.HP -= damage
+	if tower.HP < 0 {
+		tower.HP = 0
+	}
+	damage = oldHP - tower.HP
+	ge.towerHit(opponent, tower)
+
+	if damage > 0 {
+		ge.awardEXPForDamage(playerID, damage, "tower")
+	}
+
+	towerDestroyed := tower.HP == 0 && oldHP > 0
+
+	ge.broadcastAction(CombatAction{
+		Type:       ActionAttack,
+		PlayerID:   playerID,
+		TroopName:  troopName,
+		TargetType: "tower",
+		TargetName: string(tower.Name),
+		Damage:     damage,
+		Timestamp:  ge.clock.Now(),
+		Data: map[string]interface{}{
+			"target_hp":       tower.HP,
+			"old_hp":          oldHP,
+			"tower_destroyed": towerDestroyed,
+			"shield_absorbed": absorbed,
+			"ability":         abilityType,
+		},
+	})
+
+	if towerDestroyed {
+		ge.awardEXPForDestruction(playerID, "tower", tower.Name)
+
+		ge.broadcastAction(CombatAction{
+			Type:       "TOWER_DESTROYED",
+			PlayerID:   playerID,
+			TroopName:  troopName,
+			TargetType: "tower",
+			TargetName: string(tower.Name),
+			Damage:     damage,
+			Timestamp:  ge.clock.Now(),
+			Data: map[string]interface{}{
+				"destroyer": player.Username,
+				"owner":     opponent.Username,
+			},
+		})
+
+		ge.handleTowerDestroyed(opponent, tower)
+	}
+}
+
+// abilityDamageTroop deals ability or poison damage to an enemy troop on
+// the field. It ignores DEF but not shields.
+func (ge *GameEngine) abilityDamageTroop(playerID string, troopName TroopType, abilityType string, troop *Troop, damage int) {
+	player := ge.getPlayer(playerID)
+	opponent := ge.getOpponent(playerID)
+	if player == nil || opponent == nil {
+		return
+	}
+
+	damage, absorbed := ge.absorbWithShield(troopCarrier(opponent, troop), damage)
+
+	oldHP := troop.HP
+	troop.HP -= damage
+	if troop.HP < 0 {
+		troop.HP = 0
+	}
+	damage = oldHP - troop.HP
+
+	if damage > 0 {
+		ge.awardEXPForDamage(playerID, damage, "troop")
+	}
+
+	troopDestroyed := troop.HP == 0 && oldHP > 0
+
+	ge.broadcastAction(CombatAction{
+		Type:       ActionAttack,
+		PlayerID:   playerID,
+		TroopName:  troopName,
+		TargetType: "troop",
+		TargetName: string(troop.Name),
+		Damage:     damage,
+		Timestamp:  ge.clock.Now(),
+		Data: map[string]interface{}{
+			"target_hp":       troop.HP,
+			"old_hp":          oldHP,
+			"troop_destroyed": troopDestroyed,
+			"shield_absorbed": absorbed,
+			"ability":         abilityType,
+		},
+	})
+
+	if troopDestroyed {
+		ge.awardEXPForDestruction(playerID, "troop", troop.Name)
+
+		ge.broadcastAction(CombatAction{
+			Type:       "TROOP_DESTROYED",
+			PlayerID:   playerID,
+			TroopName:  troopName,
+			TargetType: "troop",
+			TargetName: string(troop.Name),
+			Damage:     damage,
+			Timestamp:  ge.clock.Now(),
+			Data: map[string]interface{}{
+				"destroyer": player.Username,
+				"owner":     opponent.Username,
+			},
+		})
+
+		ge.handleTroopDestroyed(opponent, troop)
+	}
+}
+
+// broadcastAbility emits an ability event for effects that are not a hit or a heal
+func (ge *GameEngine) broadcastAbility(ctx abilityContext, ability AbilitySpec, message string, data map[string]interface{}) {
+	data["ability"] = ability.Type
+	data["message"] = message
+
+	ge.logEvent("ABILITY", ctx.playerID, data)
+
+	ge.broadcastAction(CombatAction{
+		Type:       ActionAbility,
+		PlayerID:   ctx.playerID,
+		TroopName:  ctx.troop.Name,
+		TargetType: "troop",
+		TargetName: string(ctx.troop.Name),
+		Timestamp:  ge.clock.Now(),
+		Data:       data,
+	})
+}
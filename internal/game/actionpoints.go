@@ -0,0 +1,39 @@
+// Package game implements the Simple mode action point budget
+package game
+
+import "fmt"
+
+// AttackActionCost is what an attack takes from the turn's action points.
+// Troops and spells cost their mana.
+const AttackActionCost = 1
+
+// actionPointsOn reports whether Simple mode turns run on action points
+// instead of the cards per turn limit
+func (ge *GameEngine) actionPointsOn() bool {
+	return ge.gameState.GameMode == ModeSimple && ge.gameState.Rules.ActionPoints > 0
+}
+
+// refillActionPoints gives both players a full budget for the next turn
+func (ge *GameEngine) refillActionPoints() {
+	if !ge.actionPointsOn() {
+		return
+	}
+	ge.gameState.Player1.ActionPoints = ge.gameState.Rules.ActionPoints
+	ge.gameState.Player2.ActionPoints = ge.gameState.Rules.ActionPoints
+}
+
+// checkActionPoints rejects an action the player can't afford this turn
+func (ge *GameEngine) checkActionPoints(player *Player, cost int, action string) error {
+	if !ge.actionPointsOn() || player.ActionPoints >= cost {
+		return nil
+	}
+	return fmt.Errorf("not enough action points: %s costs %d, you have %d left this turn", action, cost, player.ActionPoints)
+}
+
+// spendActionPoints takes an action's cost from the player's budget
+func (ge *GameEngine) spendActionPoints(player *Player, cost int) {
+	if !ge.actionPointsOn() {
+		return
+	}
+	player.ActionPoints -= cost
+}
@@ -0,0 +1,238 @@
+// Package game provides the clock abstraction used for Enhanced mode timing
+package game
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Clock abstracts time so Enhanced mode can run against a real or a virtual clock
+type Clock interface {
+	Now() time.Time
+	Sleep(d time.Duration)
+	NewTicker(d time.Duration) Ticker
+	NewTimer(d time.Duration) Timer
+}
+
+// Ticker delivers ticks at a fixed period
+type Ticker interface {
+	C() <-chan time.Time
+	Stop()
+}
+
+// Timer fires once after its duration
+type Timer interface {
+	C() <-chan time.Time
+	Stop() bool
+}
+
+// RealClock is the production clock backed by the time package
+type RealClock struct{}
+
+// NewRealClock creates a clock backed by wall time
+func NewRealClock() Clock {
+	return RealClock{}
+}
+
+func (RealClock) Now() time.Time        { return time.Now() }
+func (RealClock) Sleep(d time.Duration) { time.Sleep(d) }
+
+func (RealClock) NewTicker(d time.Duration) Ticker {
+	return &realTicker{ticker: time.NewTicker(d)}
+}
+
+func (RealClock) NewTimer(d time.Duration) Timer {
+	return &realTimer{timer: time.NewTimer(d)}
+}
+
+type realTicker struct {
+	ticker *time.Ticker
+}
+
+func (t *realTicker) C() <-chan time.Time { return t.ticker.C }
+func (t *realTicker) Stop()               { t.ticker.Stop() }
+
+type realTimer struct {
+	timer *time.Timer
+}
+
+func (t *realTimer) C() <-chan time.Time { return t.timer.C }
+func (t *realTimer) Stop() bool          { return t.timer.Stop() }
+
+// FakeClock is a manually advanced clock for tests and simulations.
+// Time only moves when Advance is called, so a full 3-minute match
+// can be driven in milliseconds.
+type FakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []*fakeWaiter
+	nextSeq int
+	changed chan struct{}
+}
+
+// fakeWaiter is a pending timer, ticker or sleep on the fake clock
+type fakeWaiter struct {
+	deadline time.Time
+	period   time.Duration // > 0 for tickers
+	seq      int           // creation order, breaks ties between equal deadlines
+	ch       chan time.Time
+	stopped  chan struct{}
+	stopOnce sync.Once
+}
+
+// NewFakeClock creates a fake clock starting at the given time
+func NewFakeClock(start time.Time) *FakeClock {
+	return &FakeClock{
+		now:     start,
+		changed: make(chan struct{}),
+	}
+}
+
+// Now returns the current virtual time
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Sleep blocks until the clock has been advanced past d
+func (c *FakeClock) Sleep(d time.Duration) {
+	<-c.NewTimer(d).C()
+}
+
+// NewTicker creates a ticker that fires every d of virtual time
+func (c *FakeClock) NewTicker(d time.Duration) Ticker {
+	if d <= 0 {
+		panic("game: non-positive interval for FakeClock.NewTicker")
+	}
+	return &fakeTicker{clock: c, waiter: c.addWaiter(d, d)}
+}
+
+// NewTimer creates a timer that fires once after d of virtual time
+func (c *FakeClock) NewTimer(d time.Duration) Timer {
+	return &fakeTimer{clock: c, waiter: c.addWaiter(d, 0)}
+}
+
+// Advance moves the clock forward by d, firing every timer and ticker that
+// comes due in deadline order. Each fire blocks until it has been received
+// (or the receiver stopped), so callers observe reactions in a defined order.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	target := c.now.Add(d)
+	c.mu.Unlock()
+
+	for {
+		c.mu.Lock()
+		w := c.nextDue(target)
+		if w == nil {
+			c.now = target
+			c.mu.Unlock()
+			return
+		}
+
+		c.now = w.deadline
+		if w.period > 0 {
+			w.deadline = w.deadline.Add(w.period)
+		} else {
+			c.removeWaiter(w)
+		}
+		now := c.now
+		c.mu.Unlock()
+
+		select {
+		case w.ch <- now:
+		case <-w.stopped:
+		}
+	}
+}
+
+// BlockUntil waits until at least n timers, tickers or sleeps are pending.
+// Useful to make sure goroutines have armed their timers before advancing.
+func (c *FakeClock) BlockUntil(n int) {
+	for {
+		c.mu.Lock()
+		if len(c.waiters) >= n {
+			c.mu.Unlock()
+			return
+		}
+		changed := c.changed
+		c.mu.Unlock()
+		<-changed
+	}
+}
+
+func (c *FakeClock) addWaiter(d, period time.Duration) *fakeWaiter {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	w := &fakeWaiter{
+		deadline: c.now.Add(d),
+		period:   period,
+		seq:      c.nextSeq,
+		ch:       make(chan time.Time),
+		stopped:  make(chan struct{}),
+	}
+	c.nextSeq++
+	c.waiters = append(c.waiters, w)
+	c.notifyChanged()
+	return w
+}
+
+// nextDue returns the earliest waiter due at or before target. Caller holds c.mu.
+func (c *FakeClock) nextDue(target time.Time) *fakeWaiter {
+	sort.SliceStable(c.waiters, func(i, j int) bool {
+		if c.waiters[i].deadline.Equal(c.waiters[j].deadline) {
+			return c.waiters[i].seq < c.waiters[j].seq
+		}
+		return c.waiters[i].deadline.Before(c.waiters[j].deadline)
+	})
+
+	if len(c.waiters) == 0 || c.waiters[0].deadline.After(target) {
+		return nil
+	}
+	return c.waiters[0]
+}
+
+// removeWaiter drops w from the pending list. Caller holds c.mu.
+func (c *FakeClock) removeWaiter(w *fakeWaiter) bool {
+	for i := range c.waiters {
+		if c.waiters[i] == w {
+			c.waiters = append(c.waiters[:i], c.waiters[i+1:]...)
+			c.notifyChanged()
+			return true
+		}
+	}
+	return false
+}
+
+// notifyChanged wakes BlockUntil callers. Caller holds c.mu.
+func (c *FakeClock) notifyChanged() {
+	close(c.changed)
+	c.changed = make(chan struct{})
+}
+
+func (c *FakeClock) stopWaiter(w *fakeWaiter) bool {
+	c.mu.Lock()
+	removed := c.removeWaiter(w)
+	c.mu.Unlock()
+
+	w.stopOnce.Do(func() { close(w.stopped) })
+	return removed
+}
+
+type fakeTicker struct {
+	clock  *FakeClock
+	waiter *fakeWaiter
+}
+
+func (t *fakeTicker) C() <-chan time.Time { return t.waiter.ch }
+func (t *fakeTicker) Stop()               { t.clock.stopWaiter(t.waiter) }
+
+type fakeTimer struct {
+	clock  *FakeClock
+	waiter *fakeWaiter
+}
+
+func (t *fakeTimer) C() <-chan time.Time { return t.waiter.ch }
+func (t *fakeTimer) Stop() bool          { return t.clock.stopWaiter(t.waiter) }
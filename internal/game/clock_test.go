@@ -0,0 +1,158 @@
+package game
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// newTestEngine sets up a match between two fresh players on the shipped
+// data files, with no data manager so nothing is saved
+func newTestEngine(t *testing.T, ruleset string, seed int64) *GameEngine {
+	t.Helper()
+
+	dir := t.TempDir()
+	for _, name := range []string{"troops.json", "towers.json", "spells.json", "rules.json"} {
+		data, err := os.ReadFile(filepath.Join("..", "..", "cmd", "server", "data", name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	dm := NewDataManager(dir)
+	if err := dm.Initialize(); err != nil {
+		t.Fatal(err)
+	}
+	rules, err := dm.GetRuleset(ruleset)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rng := NewMatchRNG(seed)
+	players := make([]*Player, 2)
+	for i, username := range []string{"alice", "bob"} {
+		playerData, err := dm.RegisterPlayer(username, "secret")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if players[i], err = dm.CreatePlayerForGame(playerData, "player_"+username, rng); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return NewGameEngine(players[0], players[1], rules, dm.GetGameSpecs(), nil, seed)
+}
+
+// matchOptions sets up a test match for startMatch
+type matchOptions struct {
+	seed   int64
+	clock  bool           // Run the match on a FakeClock
+	record bool           // Record the match to a buffer
+	adjust func(*Ruleset) // When not nil, changes the ruleset before the match starts
+}
+
+// startMatch starts a test match. The clock and recording are nil unless
+// opts asks for them. A match on a ticking loop is started once the loop's
+// ticker waits on the fake clock.
+func startMatch(t *testing.T, ruleset string, opts matchOptions) (*GameEngine, *FakeClock, *bytes.Buffer) {
+	t.Helper()
+
+	ge := newTestEngine(t, ruleset, opts.seed)
+	if opts.adjust != nil {
+		opts.adjust(&ge.gameState.Rules)
+	}
+	var clock *FakeClock
+	if opts.clock {
+		clock = NewFakeClock(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+		ge.SetClock(clock)
+	}
+	var recording *bytes.Buffer
+	if opts.record {
+		recording = &bytes.Buffer{}
+		ge.SetRecorder(NewReplayWriter(recording))
+	}
+	if err := ge.StartGame(); err != nil {
+		t.Fatal(err)
+	}
+	if clock != nil && ge.clockDriven() {
+		clock.BlockUntil(1) // The loop's ticker
+	}
+	return ge, clock, recording
+}
+
+// cheapestCard returns the cheapest troop card in the player's hand
+func cheapestCard(t *testing.T, player Player) TroopType {
+	t.Helper()
+
+	var card TroopType
+	cost := 0
+	for _, name := range player.Hand {
+		for _, troop := range player.Troops {
+			if troop.Name == name && (card == "" || troop.MANA < cost) {
+				card, cost = name, troop.MANA
+			}
+		}
+	}
+	if card == "" {
+		t.Fatalf("%s has no troop in hand", player.Username)
+	}
+	return card
+}
+
+// playEnhancedMatch plays a whole Enhanced match on a fake clock: player 1
+// deploys a troop at the start, player 2 does nothing
+func playEnhancedMatch(t *testing.T, seed int64) *GameState {
+	t.Helper()
+
+	ge, clock, _ := startMatch(t, ModeEnhanced, matchOptions{seed: seed, clock: true})
+
+	player1 := ge.GetGameState().Player1
+	if _, err := ge.SummonTroop(player1.ID, cheapestCard(t, player1), LaneLeft); err != nil {
+		t.Fatal(err)
+	}
+
+	// The regular time and overtime, and a little more in case nothing ends it
+	rules := ge.GetGameState().Rules
+	matchLength := time.Duration(rules.DurationSeconds+rules.OvertimeSeconds+5) * time.Second
+	for elapsed := time.Duration(0); elapsed < matchLength && ge.IsRunning(); elapsed += time.Second {
+		clock.Advance(time.Second)
+	}
+
+	if ge.IsRunning() {
+		t.Fatal("match still running after regular time and overtime")
+	}
+	return ge.GetGameState()
+}
+
+func TestFakeClockPlaysFullEnhancedMatch(t *testing.T) {
+	state := playEnhancedMatch(t, 42)
+
+	if state.Status != StatusFinished {
+		t.Fatalf("status = %s, want %s", state.Status, StatusFinished)
+	}
+	if state.Winner != state.Player1.ID {
+		t.Fatalf("winner = %q, want player 1 (%s), the only one to deploy", state.Winner, state.Player1.ID)
+	}
+	if state.TimeLeft > 0 {
+		t.Fatalf("time left = %d, the match should have run out its clock", state.TimeLeft)
+	}
+	if state.Rules.DurationSeconds != 180 {
+		t.Fatalf("enhanced ruleset lasts %ds, this test expects a 3 minute match", state.Rules.DurationSeconds)
+	}
+
+	// The same seed on the fake clock plays out the same match, only the
+	// game ID is new
+	again := playEnhancedMatch(t, 42)
+	again.ID = state.ID
+	first, _ := json.Marshal(state)
+	second, _ := json.Marshal(again)
+	if string(first) != string(second) {
+		t.Fatalf("replaying seed 42 gave a different final state:\n%s\n%s", first, second)
+	}
+}
@@ -0,0 +1,172 @@
+// Package game implements correspondence matches: Simple mode games saved
+// between turns that players come back to hours later
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"time"
+)
+
+// CorrespondenceGame is an open correspondence match. The match itself lives
+// in its replay file, which is written as it is played.
+type CorrespondenceGame struct {
+	GameID   string    `json:"game_id"`
+	Players  [2]string `json:"players"` // Usernames, player 1 first
+	Ruleset  string    `json:"ruleset"`
+	Deadline time.Time `json:"deadline"` // ToMove forfeits when it passes
+	ToMove   string    `json:"to_move"`  // Player ID of the player the deadline is for
+}
+
+// CorrespondenceDatabase represents the correspondence.json structure
+type CorrespondenceDatabase struct {
+	Games []CorrespondenceGame `json:"games"`
+}
+
+// HasPlayer reports whether username plays in the match
+func (cg CorrespondenceGame) HasPlayer(username string) bool {
+	return cg.Players[0] == username || cg.Players[1] == username
+}
+
+// loadCorrespondenceGames loads the open correspondence matches from correspondence.json
+func (dm *DataManager) loadCorrespondenceGames() error {
+	dm.correspondenceDB = &CorrespondenceDatabase{Games: make([]CorrespondenceGame, 0)}
+	if _, err := os.Stat(dm.correspondenceFile); os.IsNotExist(err) {
+		return nil
+	}
+
+	data, err := ioutil.ReadFile(dm.correspondenceFile)
+	if err != nil {
+		return fmt.Errorf("failed to read correspondence file: %w", err)
+	}
+
+	if err := json.Unmarshal(data, dm.correspondenceDB); err != nil {
+		return fmt.Errorf("failed to parse correspondence JSON: %w", err)
+	}
+	return nil
+}
+
+// saveCorrespondenceGames writes correspondence.json. Callers hold dm.mu.
+func (dm *DataManager) saveCorrespondenceGames() error {
+	data, err := json.MarshalIndent(dm.correspondenceDB, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal correspondence games: %w", err)
+	}
+
+	if err := ioutil.WriteFile(dm.correspondenceFile, data, 0644); err != nil {
+		return fmt.Errorf("failed to write correspondence file: %w", err)
+	}
+	return nil
+}
+
+// CorrespondenceGames returns a copy of the open correspondence matches
+func (dm *DataManager) CorrespondenceGames() []CorrespondenceGame {
+	dm.mu.Lock()
+	defer dm.mu.Unlock()
+
+	return append([]CorrespondenceGame(nil), dm.correspondenceDB.Games...)
+}
+
+// SaveCorrespondenceGame stores a correspondence match, replacing the one
+// with the same game ID
+func (dm *DataManager) SaveCorrespondenceGame(cg CorrespondenceGame) error {
+	dm.mu.Lock()
+	defer dm.mu.Unlock()
+
+	for i := range dm.correspondenceDB.Games {
+		if dm.correspondenceDB.Games[i].GameID == cg.GameID {
+			dm.correspondenceDB.Games[i] = cg
+			return dm.saveCorrespondenceGames()
+		}
+	}
+
+	dm.correspondenceDB.Games = append(dm.correspondenceDB.Games, cg)
+	return dm.saveCorrespondenceGames()
+}
+
+// RemoveCorrespondenceGame drops a finished correspondence match
+func (dm *DataManager) RemoveCorrespondenceGame(gameID string) error {
+	dm.mu.Lock()
+	defer dm.mu.Unlock()
+
+	for i := range dm.correspondenceDB.Games {
+		if dm.correspondenceDB.Games[i].GameID == gameID {
+			dm.correspondenceDB.Games = append(dm.correspondenceDB.Games[:i], dm.correspondenceDB.Games[i+1:]...)
+			return dm.saveCorrespondenceGames()
+		}
+	}
+	return nil
+}
+
+// ResumeGame rebuilds an unfinished correspondence match from its replay by
+// re-running the recorded commands, then hands it back live: EXP is saved
+// through dataManager from here on and recording carries on with recorder.
+// The re-run's events were sent to the players the first time and are dropped.
+func ResumeGame(replay *Replay, dataManager *DataManager, recorder *ReplayRecorder) (*GameEngine, error) {
+	header := replay.Header
+	if header.InitialState == nil || header.Specs == nil {
+		return nil, fmt.Errorf("replay header is missing initial state or specs")
+	}
+	if replay.FinalState != nil {
+		return nil, fmt.Errorf("match %s has already finished", header.GameID)
+	}
+	if !header.InitialState.Rules.Correspondence() {
+		return nil, fmt.Errorf("match %s is not a correspondence match", header.GameID)
+	}
+
+	player1 := header.InitialState.Player1.clone()
+	player2 := header.InitialState.Player2.clone()
+
+	// No data manager yet: re-running the commands must not award EXP twice
+	ge := NewGameEngine(&player1, &player2, header.InitialState.Rules, header.Specs, nil, header.Seed)
+	ge.gameState.ID = header.InitialState.ID
+	ge.gameState.StartTime = header.InitialState.StartTime
+
+	if err := ge.StartGame(); err != nil {
+		return nil, fmt.Errorf("failed to start match: %w", err)
+	}
+
+	for _, entry := range replay.Commands {
+		if err := ge.applyReplayCommand(*entry.Command); err != nil {
+			ge.StopGame()
+			return nil, fmt.Errorf("command %d (%s) rejected on resume: %w", entry.Seq, entry.Command.Type, err)
+		}
+	}
+	if !ge.IsRunning() {
+		return nil, fmt.Errorf("match %s ended while resuming", header.GameID)
+	}
+
+	ge.submit(func() (*CombatAction, error) {
+		ge.dataManager = dataManager
+		ge.recorder = recorder
+		ge.eventQueue = ge.eventQueue[:0]
+		for len(ge.eventChan) > 0 {
+			<-ge.eventChan
+		}
+		return nil, nil
+	})
+
+	ge.logger.Info("Resumed correspondence match %s after %d commands", header.GameID, len(replay.Commands))
+	return ge, nil
+}
+
+// ForfeitMoveDeadline forfeits the match for the player to move once their
+// correspondence move deadline has passed
+func (ge *GameEngine) ForfeitMoveDeadline(playerID string) error {
+	_, err := ge.submit(func() (*CombatAction, error) {
+		if !ge.isRunning {
+			return nil, fmt.Errorf("game is not running")
+		}
+		if ge.gameState.CurrentTurn != playerID {
+			return nil, fmt.Errorf("not %s's turn", playerID)
+		}
+
+		ge.logger.Info("Move deadline passed for %s in %s", ge.getPlayer(playerID).Username, ge.gameState.ID)
+		ge.forfeit(playerID, EndMoveDeadline)
+		ge.recordCommand(ReplayCommand{Type: ReplayCommandMoveDeadline, PlayerID: playerID})
+		return nil, nil
+	})
+	return err
+}
@@ -0,0 +1,108 @@
+package game
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// opponentOf returns the ID of the other player in the match
+func opponentOf(ge *GameEngine, playerID string) string {
+	state := ge.GetGameState()
+	if state.Player1.ID == playerID {
+		return state.Player2.ID
+	}
+	return state.Player1.ID
+}
+
+func TestResumeGameRebuildsMatch(t *testing.T) {
+	ge, _, recording := startMatch(t, "correspondence", matchOptions{seed: 9, record: true})
+
+	// Two full turns and half of the third, as if the server went down
+	// while the third player to move was thinking
+	for turn := 0; turn < 3; turn++ {
+		me := playerState(ge, ge.GetGameState().CurrentTurn)
+		if _, err := ge.SummonTroop(me.ID, cheapestCard(t, me), LaneLeft); err != nil {
+			t.Fatal(err)
+		}
+		if turn < 2 {
+			if err := ge.EndTurn(me.ID); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	played := ge.GetGameState()
+	saved := append([]byte(nil), recording.Bytes()...)
+	ge.StopGame()
+
+	replay, err := ReadReplay(bytes.NewReader(saved))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resumed, err := ResumeGame(replay, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resumed.StopGame()
+
+	// The start time is taken from the recording, so the whole state matches
+	rebuilt := resumed.GetGameState()
+	want, _ := json.Marshal(played)
+	got, _ := json.Marshal(rebuilt)
+	if string(want) != string(got) {
+		t.Fatalf("resumed match differs from the one played:\nplayed:  %s\nresumed: %s", want, got)
+	}
+
+	// The player to move carries on where they left off
+	if err := resumed.EndTurn(rebuilt.CurrentTurn); err != nil {
+		t.Fatalf("ending the resumed turn: %v", err)
+	}
+}
+
+func TestResumeGameRejectsFinishedMatch(t *testing.T) {
+	ge, _, recording := startMatch(t, "correspondence", matchOptions{seed: 9, record: true})
+	if err := ge.Surrender(ge.GetGameState().CurrentTurn); err != nil {
+		t.Fatal(err)
+	}
+	<-ge.loopDone
+
+	replay, err := ReadReplay(recording)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ResumeGame(replay, nil, nil); err == nil || !strings.Contains(err.Error(), "already finished") {
+		t.Fatalf("ResumeGame() on a finished match = %v, want an already finished error", err)
+	}
+}
+
+func TestForfeitMoveDeadlineOnlyForPlayerToMove(t *testing.T) {
+	ge, _, recording := startMatch(t, "correspondence", matchOptions{seed: 9, record: true})
+	toMove := ge.GetGameState().CurrentTurn
+	waiting := opponentOf(ge, toMove)
+
+	// A deadline saved for the previous turn must not forfeit the player who
+	// already moved
+	if err := ge.ForfeitMoveDeadline(waiting); err == nil {
+		t.Fatalf("ForfeitMoveDeadline(%s) accepted while it is %s's turn", waiting, toMove)
+	}
+	if !ge.IsRunning() {
+		t.Fatal("a refused deadline forfeit ended the match")
+	}
+
+	if err := ge.ForfeitMoveDeadline(toMove); err != nil {
+		t.Fatal(err)
+	}
+	<-ge.loopDone
+
+	if winner := ge.GetGameState().Winner; winner != waiting {
+		t.Fatalf("winner = %q, want %s", winner, waiting)
+	}
+	replay, err := ReadReplay(recording)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reason := gameEndReason(replay); reason != EndMoveDeadline {
+		t.Fatalf("GAME_END reason = %q, want %q", reason, EndMoveDeadline)
+	}
+}
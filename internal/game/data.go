@@ -8,6 +8,8 @@ import (
 	"math/rand"
 	"os"
 	"path/filepath"
+	"sort"
+	"sync"
 	"time"
 )
 
@@ -16,9 +18,17 @@ type DataManager struct {
 	dataDir     string
 	troopsFile  string
 	towersFile  string
+	spellsFile  string
+	rulesFile   string
 	playersFile string
 	gameSpecs   *GameSpecs
+	deckSize    int
+	rulesets    map[string]Ruleset
 	playerDB    *PlayerDatabase
+	mu          sync.Mutex // Guards playerDB and correspondenceDB, shared by connection handlers and game loops
+
+	correspondenceFile string
+	correspondenceDB   *CorrespondenceDatabase
 }
 
 // PlayerDatabase represents the player database structure
@@ -32,7 +42,11 @@ func NewDataManager(dataDir string) *DataManager {
 		dataDir:     dataDir,
 		troopsFile:  filepath.Join(dataDir, "troops.json"),
 		towersFile:  filepath.Join(dataDir, "towers.json"),
+		spellsFile:  filepath.Join(dataDir, "spells.json"),
+		rulesFile:   filepath.Join(dataDir, "rules.json"),
 		playersFile: filepath.Join(dataDir, "players.json"),
+
+		correspondenceFile: filepath.Join(dataDir, "correspondence.json"),
 	}
 }
 
@@ -46,14 +60,22 @@ func (dm *DataManager) Initialize() error {
 		return fmt.Errorf("failed to load game specs: %w", err)
 	}
 
+	if err := dm.loadRules(); err != nil {
+		return fmt.Errorf("failed to load rules: %w", err)
+	}
+
 	if err := dm.loadPlayerDatabase(); err != nil {
 		return fmt.Errorf("failed to load player database: %w", err)
 	}
 
+	if err := dm.loadCorrespondenceGames(); err != nil {
+		return fmt.Errorf("failed to load correspondence games: %w", err)
+	}
+
 	return nil
 }
 
-// loadGameSpecs loads troop and tower specifications from JSON files
+// loadGameSpecs loads troop, tower and spell specifications from JSON files
 func (dm *DataManager) loadGameSpecs() error {
 	troopSpecs, err := dm.loadTroopSpecs()
 	if err != nil {
@@ -65,10 +87,33 @@ func (dm *DataManager) loadGameSpecs() error {
 		return err
 	}
 
+	spellSpecs, err := dm.loadSpellSpecs()
+	if err != nil {
+		return err
+	}
+
+	if err := ValidateTroops(troopSpecs); err != nil {
+		return err
+	}
+
+	if err := ValidateAbilities(troopSpecs); err != nil {
+		return err
+	}
+
+	if err := ValidateSpells(spellSpecs); err != nil {
+		return err
+	}
+
+	if err := ValidateTowers(towerSpecs); err != nil {
+		return err
+	}
+
 	dm.gameSpecs = &GameSpecs{
 		TroopSpecs: troopSpecs,
 		TowerSpecs: towerSpecs,
+		SpellSpecs: spellSpecs,
 	}
+	dm.gameSpecs.Version = SpecsVersion(dm.gameSpecs)
 
 	return nil
 }
@@ -109,6 +154,51 @@ func (dm *DataManager) loadTowerSpecs() (map[TowerType]TowerSpec, error) {
 	return towerData.Towers, nil
 }
 
+// loadSpellSpecs loads spell specifications from spells.json
+func (dm *DataManager) loadSpellSpecs() (map[SpellType]SpellSpec, error) {
+	data, err := ioutil.ReadFile(dm.spellsFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read spells file: %w", err)
+	}
+
+	var spellData struct {
+		Spells map[SpellType]SpellSpec `json:"spells"`
+	}
+
+	if err := json.Unmarshal(data, &spellData); err != nil {
+		return nil, fmt.Errorf("failed to parse spells JSON: %w", err)
+	}
+
+	return spellData.Spells, nil
+}
+
+// loadRules loads and validates the deck size and match rulesets from rules.json
+func (dm *DataManager) loadRules() error {
+	data, err := ioutil.ReadFile(dm.rulesFile)
+	if err != nil {
+		return fmt.Errorf("failed to read rules file: %w", err)
+	}
+
+	var rules rulesConfig
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return fmt.Errorf("failed to parse rules JSON: %w", err)
+	}
+
+	// A ruleset is named by its key
+	for name, ruleset := range rules.Rulesets {
+		ruleset.Name = name
+		rules.Rulesets[name] = ruleset
+	}
+
+	if err := ValidateRules(rules.DeckSize, rules.Rulesets, len(dm.gameSpecs.TroopSpecs)); err != nil {
+		return err
+	}
+
+	dm.deckSize = rules.DeckSize
+	dm.rulesets = rules.Rulesets
+	return nil
+}
+
 // loadPlayerDatabase loads player data from players.json
 func (dm *DataManager) loadPlayerDatabase() error {
 	if _, err := os.Stat(dm.playersFile); os.IsNotExist(err) {
@@ -148,6 +238,9 @@ func (dm *DataManager) savePlayerDatabase() error {
 // Authentication methods
 
 func (dm *DataManager) AuthenticatePlayer(username, password string) (*PlayerData, error) {
+	dm.mu.Lock()
+	defer dm.mu.Unlock()
+
 	for i := range dm.playerDB.Players {
 		player := &dm.playerDB.Players[i]
 		if player.Username == username {
@@ -168,6 +261,9 @@ func (dm *DataManager) AuthenticatePlayer(username, password string) (*PlayerDat
 
 // RegisterPlayer creates a new player account
 func (dm *DataManager) RegisterPlayer(username, password string) (*PlayerData, error) {
+	dm.mu.Lock()
+	defer dm.mu.Unlock()
+
 	for _, player := range dm.playerDB.Players {
 		if player.Username == username {
 			return nil, fmt.Errorf("username already exists")
@@ -206,6 +302,9 @@ func (dm *DataManager) RegisterPlayer(username, password string) (*PlayerData, e
 
 // ✅ UPDATED: UpdatePlayerData with improved EXP and level system
 func (dm *DataManager) UpdatePlayerData(username string, expGained int, won bool, trophyChange int) error {
+	dm.mu.Lock()
+	defer dm.mu.Unlock()
+
 	for i := range dm.playerDB.Players {
 		player := &dm.playerDB.Players[i]
 		if player.Username == username {
@@ -285,56 +384,120 @@ func (dm *DataManager) calculateRequiredEXP(level int) int {
 	return int(baseEXP)
 }
 
-func (dm *DataManager) CreatePlayerForGame(playerData *PlayerData, playerID string) *Player {
+// CreatePlayerForGame builds the in-match player from their active deck. Players
+// without one get random troops drawn from the match RNG. An active deck that
+// is no longer valid, e.g. after a spec change, is rejected.
+func (dm *DataManager) CreatePlayerForGame(playerData *PlayerData, playerID string, rng *rand.Rand) (*Player, error) {
+	troops, err := dm.generateTroops(playerData, rng)
+	if err != nil {
+		return nil, err
+	}
+
 	player := &Player{
 		ID:       playerID,
 		Username: playerData.Username,
 		Level:    playerData.Level,
 		EXP:      playerData.EXP,
-		Mana:     StartingMana,
-		MaxMana:  MaxMana,
-		Troops:   dm.generateRandomTroops(playerData),
+		Troops:   troops,
 		Towers:   dm.generateTowers(playerData),
+		Spells:   dm.generateSpells(),
+	}
+
+	return player, nil
+}
+
+// generateTroops builds the troops of the player's active deck, or random ones
+func (dm *DataManager) generateTroops(playerData *PlayerData, rng *rand.Rand) ([]Troop, error) {
+	if playerData.ActiveDeck == "" {
+		return dm.generateRandomTroops(playerData, rng), nil
+	}
+
+	deck := playerData.FindDeck(playerData.ActiveDeck)
+	if deck == nil {
+		return nil, fmt.Errorf("active deck %q not found", playerData.ActiveDeck)
+	}
+	if err := dm.ValidateDeck(playerData, *deck); err != nil {
+		return nil, fmt.Errorf("active deck %q: %w", deck.Name, err)
 	}
 
-	return player
+	// Shuffle a copy so the deck is dealt in a different order every match
+	cards := append([]TroopType(nil), deck.Cards...)
+	rng.Shuffle(len(cards), func(i, j int) {
+		cards[i], cards[j] = cards[j], cards[i]
+	})
+
+	troops := make([]Troop, len(cards))
+	for i, troopType := range cards {
+		troops[i] = dm.buildTroop(troopType, playerData.TroopLevels[troopType])
+	}
+	return troops, nil
 }
 
-// generateRandomTroops generates 3 random troops for a player
-func (dm *DataManager) generateRandomTroops(playerData *PlayerData) []Troop {
+// generateRandomTroops deals a random deck of deck size troops
+func (dm *DataManager) generateRandomTroops(playerData *PlayerData, rng *rand.Rand) []Troop {
 	troopTypes := make([]TroopType, 0, len(dm.gameSpecs.TroopSpecs))
 	for troopType := range dm.gameSpecs.TroopSpecs {
 		troopTypes = append(troopTypes, troopType)
 	}
 
-	// Shuffle and pick 3 random troops
-	rand.Shuffle(len(troopTypes), func(i, j int) {
+	// Map iteration order is random, sort first so the same seed gives the same draw
+	sort.Slice(troopTypes, func(i, j int) bool { return troopTypes[i] < troopTypes[j] })
+
+	// Shuffle and pick a deck
+	rng.Shuffle(len(troopTypes), func(i, j int) {
 		troopTypes[i], troopTypes[j] = troopTypes[j], troopTypes[i]
 	})
 
-	troops := make([]Troop, TroopsPerPlayer)
-	for i := 0; i < TroopsPerPlayer; i++ {
+	deckSize := dm.deckSize
+	if deckSize > len(troopTypes) {
+		deckSize = len(troopTypes)
+	}
+
+	troops := make([]Troop, deckSize)
+	for i := 0; i < deckSize; i++ {
 		troopType := troopTypes[i]
-		baseSpec := dm.gameSpecs.TroopSpecs[troopType]
-		playerLevel := playerData.TroopLevels[troopType]
-
-		troops[i] = Troop{
-			Name:    troopType,
-			HP:      dm.scaleStatByLevel(baseSpec.HP, playerLevel),
-			MaxHP:   dm.scaleStatByLevel(baseSpec.HP, playerLevel),
-			ATK:     dm.scaleStatByLevel(baseSpec.ATK, playerLevel),
-			DEF:     dm.scaleStatByLevel(baseSpec.DEF, playerLevel),
-			CRIT:    baseSpec.CRIT,
-			MANA:    baseSpec.MANA,
-			EXP:     baseSpec.EXP,
-			Special: baseSpec.Special,
-			Level:   playerLevel,
-		}
+		troops[i] = dm.buildTroop(troopType, playerData.TroopLevels[troopType])
 	}
 
 	return troops
 }
 
+// buildTroop creates a troop with its stats scaled to the player's level
+func (dm *DataManager) buildTroop(troopType TroopType, playerLevel int) Troop {
+	baseSpec := dm.gameSpecs.TroopSpecs[troopType]
+
+	return Troop{
+		Name:      troopType,
+		HP:        dm.scaleStatByLevel(baseSpec.HP, playerLevel),
+		MaxHP:     dm.scaleStatByLevel(baseSpec.HP, playerLevel),
+		ATK:       dm.scaleStatByLevel(baseSpec.ATK, playerLevel),
+		DEF:       dm.scaleStatByLevel(baseSpec.DEF, playerLevel),
+		CRIT:      baseSpec.CRIT,
+		MANA:      baseSpec.MANA,
+		EXP:       baseSpec.EXP,
+		HitSpeed:  baseSpec.HitSpeed,
+		MoveSpeed: baseSpec.MoveSpeed,
+		Special:   baseSpec.Special,
+		Level:     playerLevel,
+	}
+}
+
+// generateSpells gives a player every spell card, in a stable order
+func (dm *DataManager) generateSpells() []Spell {
+	spells := make([]Spell, 0, len(dm.gameSpecs.SpellSpecs))
+	for _, spellType := range sortedSpellTypes(dm.gameSpecs.SpellSpecs) {
+		spec := dm.gameSpecs.SpellSpecs[spellType]
+		spells = append(spells, Spell{
+			Name:         spellType,
+			MANA:         spec.MANA,
+			Target:       spec.Target(),
+			Description:  spec.Description,
+			EnhancedOnly: spec.EnhancedOnly(),
+		})
+	}
+	return spells
+}
+
 // generateTowers generates towers for a player
 func (dm *DataManager) generateTowers(playerData *PlayerData) []Tower {
 	towers := make([]Tower, TowersPerPlayer)
@@ -347,7 +510,7 @@ func (dm *DataManager) generateTowers(playerData *PlayerData) []Tower {
 	guardLevel1 := playerData.TowerLevels[GuardTower1]
 	guardLevel2 := playerData.TowerLevels[GuardTower2]
 
-	// King Tower
+	// King Tower, dormant until a Guard Tower falls or it is hit
 	towers[0] = Tower{
 		Name:     KingTower,
 		HP:       dm.scaleStatByLevel(kingSpec.HP, kingLevel),
@@ -355,8 +518,9 @@ func (dm *DataManager) generateTowers(playerData *PlayerData) []Tower {
 		ATK:      dm.scaleStatByLevel(kingSpec.ATK, kingLevel),
 		DEF:      dm.scaleStatByLevel(kingSpec.DEF, kingLevel),
 		CRIT:     kingSpec.CRIT,
+		HitSpeed: kingSpec.HitSpeed,
 		Level:    kingLevel,
-		IsActive: true,
+		IsActive: false,
 	}
 
 	// Guard Tower 1
@@ -367,6 +531,7 @@ func (dm *DataManager) generateTowers(playerData *PlayerData) []Tower {
 		ATK:      dm.scaleStatByLevel(guardSpec1.ATK, guardLevel1),
 		DEF:      dm.scaleStatByLevel(guardSpec1.DEF, guardLevel1),
 		CRIT:     guardSpec1.CRIT,
+		HitSpeed: guardSpec1.HitSpeed,
 		Level:    guardLevel1,
 		IsActive: true,
 	}
@@ -379,6 +544,7 @@ func (dm *DataManager) generateTowers(playerData *PlayerData) []Tower {
 		ATK:      dm.scaleStatByLevel(guardSpec2.ATK, guardLevel2),
 		DEF:      dm.scaleStatByLevel(guardSpec2.DEF, guardLevel2),
 		CRIT:     guardSpec2.CRIT,
+		HitSpeed: guardSpec2.HitSpeed,
 		Level:    guardLevel2,
 		IsActive: true,
 	}
@@ -403,6 +569,25 @@ func (dm *DataManager) GetGameSpecs() *GameSpecs {
 	return dm.gameSpecs
 }
 
+// DeckSize returns the number of troop cards in a deck
+func (dm *DataManager) DeckSize() int {
+	return dm.deckSize
+}
+
+// GetRuleset returns the ruleset with the given name
+func (dm *DataManager) GetRuleset(name string) (Ruleset, error) {
+	ruleset, ok := dm.rulesets[name]
+	if !ok {
+		return Ruleset{}, fmt.Errorf("unknown ruleset %q", name)
+	}
+	return ruleset, nil
+}
+
+// ReplayDir returns the directory match replays are recorded to
+func (dm *DataManager) ReplayDir() string {
+	return filepath.Join(dm.dataDir, "replays")
+}
+
 // GetPlayerDatabase returns the player database (for server use)
 func (dm *DataManager) GetPlayerDatabase() *PlayerDatabase {
 	return dm.playerDB
@@ -410,6 +595,9 @@ func (dm *DataManager) GetPlayerDatabase() *PlayerDatabase {
 
 // ✅ NEW: GetPlayerByUsername for easier access
 func (dm *DataManager) GetPlayerByUsername(username string) *PlayerData {
+	dm.mu.Lock()
+	defer dm.mu.Unlock()
+
 	for i := range dm.playerDB.Players {
 		if dm.playerDB.Players[i].Username == username {
 			return &dm.playerDB.Players[i]
@@ -418,16 +606,6 @@ func (dm *DataManager) GetPlayerByUsername(username string) *PlayerData {
 	return nil
 }
 
-func (dm *DataManager) CalculateGameEndEXP(won bool, isDraw bool) int {
-	if isDraw {
-		return DrawEXP
-	} else if won {
-		return WinEXP
-	} else {
-		return LoseEXP
-	}
-}
-
 func (dm *DataManager) CalculateDamageEXP(damage int) int {
 	expGained := damage / DamageEXPRatio
 	if expGained < 1 {
@@ -454,26 +632,37 @@ func (dm *DataManager) CalculateDestructionEXP(targetType string, targetName int
 	return 0
 }
 
+// NewMatchRNG creates the per-match random source for the given seed
+func NewMatchRNG(seed int64) *rand.Rand {
+	return rand.New(rand.NewSource(seed))
+}
+
 // Helper function for game engine
-func initializePlayerForGame(player *Player, specs *GameSpecs) {
+func initializePlayerForGame(player *Player, specs *GameSpecs, rules Ruleset) {
 	// This function is called by GameEngine to ensure player has proper stats
 	// The stats are already calculated by DataManager, so this is just validation
 
-	// Ensure mana is set correctly for game mode
+	// Ensure mana is set correctly for the ruleset
 	if player.Mana == 0 {
-		player.Mana = StartingMana
-	}
-	if player.MaxMana == 0 {
-		player.MaxMana = MaxMana
+		player.Mana = rules.StartingMana
 	}
+	player.MaxMana = rules.MaxMana
 
 	for i := range player.Troops {
 		player.Troops[i].MaxHP = player.Troops[i].HP
 	}
+
+	// Replays start from a recorded state that already has its hand
+	if len(player.Hand) == 0 {
+		dealHand(player, rules.HandSize)
+	}
 }
 
 // LogoutPlayer marks a player as inactive
 func (dm *DataManager) LogoutPlayer(username string) error {
+	dm.mu.Lock()
+	defer dm.mu.Unlock()
+
 	for i := range dm.playerDB.Players {
 		player := &dm.playerDB.Players[i]
 		if player.Username == username {
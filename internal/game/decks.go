@@ -0,0 +1,121 @@
+// Package game handles player-built decks
+package game
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// FindDeck returns the player's deck with the given name, or nil
+func (pd *PlayerData) FindDeck(name string) *Deck {
+	for i := range pd.Decks {
+		if pd.Decks[i].Name == name {
+			return &pd.Decks[i]
+		}
+	}
+	return nil
+}
+
+// OwnedTroops lists the troop cards the player owns, in a stable order
+func (pd *PlayerData) OwnedTroops() []TroopType {
+	owned := make([]TroopType, 0, len(pd.TroopLevels))
+	for troopType := range pd.TroopLevels {
+		owned = append(owned, troopType)
+	}
+	sort.Slice(owned, func(i, j int) bool { return owned[i] < owned[j] })
+	return owned
+}
+
+// ValidateDeck checks a deck holds deck size different troops that exist in
+// the specs and that the player owns
+func (dm *DataManager) ValidateDeck(playerData *PlayerData, deck Deck) error {
+	name := strings.TrimSpace(deck.Name)
+	if name == "" || len(name) > MaxDeckNameLength {
+		return fmt.Errorf("deck name must be 1-%d characters", MaxDeckNameLength)
+	}
+
+	if len(deck.Cards) != dm.deckSize {
+		return fmt.Errorf("deck must have exactly %d cards, has %d", dm.deckSize, len(deck.Cards))
+	}
+
+	seen := make(map[TroopType]bool, len(deck.Cards))
+	for _, card := range deck.Cards {
+		if seen[card] {
+			return fmt.Errorf("%s is in the deck more than once", card)
+		}
+		seen[card] = true
+
+		if _, ok := dm.gameSpecs.TroopSpecs[card]; !ok {
+			return fmt.Errorf("unknown card %s", card)
+		}
+		if _, ok := playerData.TroopLevels[card]; !ok {
+			return fmt.Errorf("you don't own %s", card)
+		}
+	}
+
+	return nil
+}
+
+// SaveDeck validates and stores a deck, replacing the player's deck with the
+// same name. Returns the stored player data.
+func (dm *DataManager) SaveDeck(username string, deck Deck) (*PlayerData, error) {
+	dm.mu.Lock()
+	defer dm.mu.Unlock()
+
+	player := dm.findPlayer(username)
+	if player == nil {
+		return nil, fmt.Errorf("player not found")
+	}
+
+	deck.Name = strings.TrimSpace(deck.Name)
+	if err := dm.ValidateDeck(player, deck); err != nil {
+		return nil, err
+	}
+
+	if existing := player.FindDeck(deck.Name); existing != nil {
+		*existing = deck
+	} else {
+		if len(player.Decks) >= MaxDecksPerPlayer {
+			return nil, fmt.Errorf("cannot save more than %d decks", MaxDecksPerPlayer)
+		}
+		player.Decks = append(player.Decks, deck)
+	}
+
+	if err := dm.savePlayerDatabase(); err != nil {
+		return nil, err
+	}
+	return player, nil
+}
+
+// SetActiveDeck picks the deck the player brings to matches. An empty name
+// goes back to random troops. Returns the stored player data.
+func (dm *DataManager) SetActiveDeck(username, deckName string) (*PlayerData, error) {
+	dm.mu.Lock()
+	defer dm.mu.Unlock()
+
+	player := dm.findPlayer(username)
+	if player == nil {
+		return nil, fmt.Errorf("player not found")
+	}
+
+	if deckName != "" && player.FindDeck(deckName) == nil {
+		return nil, fmt.Errorf("deck %q not found", deckName)
+	}
+
+	player.ActiveDeck = deckName
+	if err := dm.savePlayerDatabase(); err != nil {
+		return nil, err
+	}
+	return player, nil
+}
+
+// findPlayer returns the stored player with the given username. Callers hold dm.mu.
+func (dm *DataManager) findPlayer(username string) *PlayerData {
+	for i := range dm.playerDB.Players {
+		if dm.playerDB.Players[i].Username == username {
+			return &dm.playerDB.Players[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,220 @@
+// Package game implements timed status effects on troops and towers
+package game
+
+import "fmt"
+
+// Status effect types
+const (
+	EffectStun   = "stun"   // Can't attack, a stunned tower holds its fire
+	EffectSlow   = "slow"   // ATK lowered by the effect's ATKMod
+	EffectPoison = "poison" // Loses Damage HP every step, ignores DEF
+	EffectShield = "shield" // Absorbs up to Shield damage before HP
+	EffectRage   = "rage"   // ATK raised by the effect's ATKMod
+)
+
+// Reasons an effect is removed, sent with ActionEffectRemoved
+const (
+	effectExpired  = "expired"
+	effectDepleted = "depleted" // Shield used up
+	effectReplaced = "replaced" // Same type applied again
+	effectCleared  = "cleared"  // Carrier was destroyed
+)
+
+// effectCarrier is a troop or tower that can carry status effects.
+// Exactly one of troop and tower is set.
+type effectCarrier struct {
+	owner      *Player
+	troop      *Troop
+	tower      *Tower
+	targetType string // "troop" or "tower"
+	name       string
+	effects    *[]StatusEffect
+}
+
+func troopCarrier(owner *Player, troop *Troop) effectCarrier {
+	return effectCarrier{owner: owner, troop: troop, targetType: "troop", name: string(troop.Name), effects: &troop.Effects}
+}
+
+func towerCarrier(owner *Player, tower *Tower) effectCarrier {
+	return effectCarrier{owner: owner, tower: tower, targetType: "tower", name: string(tower.Name), effects: &tower.Effects}
+}
+
+// hasEffect reports whether any of the effects is of the given type
+func hasEffect(effects []StatusEffect, effectType string) bool {
+	for _, effect := range effects {
+		if effect.Type == effectType {
+			return true
+		}
+	}
+	return false
+}
+
+// effectiveATK applies the ATK modifiers of the active effects, never below 0
+func effectiveATK(base int, effects []StatusEffect) int {
+	for _, effect := range effects {
+		base += effect.ATKMod
+	}
+	if base < 0 {
+		return 0
+	}
+	return base
+}
+
+// effectiveDEF applies the DEF modifiers of the active effects, never below 0
+func effectiveDEF(base int, effects []StatusEffect) int {
+	for _, effect := range effects {
+		base += effect.DEFMod
+	}
+	if base < 0 {
+		return 0
+	}
+	return base
+}
+
+// applyEffect puts an effect on a troop or tower. Effects of the same type
+// don't stack: the new one replaces the old.
+func (ge *GameEngine) applyEffect(carrier effectCarrier, effect StatusEffect) {
+	for i := range *carrier.effects {
+		if (*carrier.effects)[i].Type == effect.Type {
+			ge.removeEffect(carrier, i, effectReplaced)
+			break
+		}
+	}
+	*carrier.effects = append(*carrier.effects, effect)
+
+	ge.logEvent("EFFECT_APPLIED", effect.SourceID, map[string]interface{}{
+		"effect":    effect.Type,
+		"source":    effect.Source,
+		"target":    carrier.name,
+		"owner":     carrier.owner.Username,
+		"remaining": effect.Remaining,
+	})
+
+	ge.broadcastAction(ge.effectAction(ActionEffectApplied, carrier, effect, map[string]interface{}{
+		"message": fmt.Sprintf("%s is affected by %s", carrier.name, describeEffect(effect)),
+	}))
+}
+
+// removeEffect takes the effect at index off the carrier
+func (ge *GameEngine) removeEffect(carrier effectCarrier, index int, reason string) {
+	effect := (*carrier.effects)[index]
+	*carrier.effects = append((*carrier.effects)[:index], (*carrier.effects)[index+1:]...)
+	if len(*carrier.effects) == 0 {
+		*carrier.effects = nil
+	}
+
+	ge.broadcastAction(ge.effectAction(ActionEffectRemoved, carrier, effect, map[string]interface{}{
+		"reason":  reason,
+		"message": fmt.Sprintf("%s on %s wore off (%s)", effect.Type, carrier.name, reason),
+	}))
+}
+
+// clearEffects removes every effect, e.g. when the carrier is destroyed
+func (ge *GameEngine) clearEffects(carrier effectCarrier) {
+	for len(*carrier.effects) > 0 {
+		ge.removeEffect(carrier, 0, effectCleared)
+	}
+}
+
+func (ge *GameEngine) effectAction(actionType string, carrier effectCarrier, effect StatusEffect, data map[string]interface{}) CombatAction {
+	data["effect"] = effect.Type
+	data["remaining"] = effect.Remaining
+	data["owner_id"] = carrier.owner.ID
+	data["owner"] = carrier.owner.Username
+
+	return CombatAction{
+		Type:       actionType,
+		PlayerID:   effect.SourceID,
+		TroopName:  effect.Source,
+		TargetType: carrier.targetType,
+		TargetName: carrier.name,
+		Timestamp:  ge.clock.Now(),
+		Data:       data,
+	}
+}
+
+// absorbWithShield takes damage off the carrier's shield first and returns what is left
+func (ge *GameEngine) absorbWithShield(carrier effectCarrier, damage int) (remaining, absorbed int) {
+	if damage <= 0 {
+		return damage, 0
+	}
+
+	for i := range *carrier.effects {
+		shield := &(*carrier.effects)[i]
+		if shield.Type != EffectShield {
+			continue
+		}
+
+		absorbed = damage
+		if absorbed > shield.Shield {
+			absorbed = shield.Shield
+		}
+		shield.Shield -= absorbed
+
+		if shield.Shield <= 0 {
+			ge.removeEffect(carrier, i, effectDepleted)
+		}
+		return damage - absorbed, absorbed
+	}
+
+	return damage, 0
+}
+
+// tickEffects advances the effects on the players' troops and towers by one
+// step: a second in Enhanced mode, one of the owner's turns in Simple mode.
+// Poison deals its damage, then effects that ran out are removed.
+func (ge *GameEngine) tickEffects(players ...*Player) {
+	for _, player := range players {
+		for i := range player.Troops {
+			ge.tickCarrier(troopCarrier(player, &player.Troops[i]))
+		}
+		for i := range player.Towers {
+			ge.tickCarrier(towerCarrier(player, &player.Towers[i]))
+		}
+	}
+}
+
+func (ge *GameEngine) tickCarrier(carrier effectCarrier) {
+	// Poison may destroy the carrier and clear its effects, so work on a copy
+	for _, effect := range append([]StatusEffect(nil), *carrier.effects...) {
+		if effect.Damage <= 0 || !ge.isRunning {
+			continue
+		}
+
+		if carrier.troop != nil && carrier.troop.HP > 0 {
+			ge.abilityDamageTroop(effect.SourceID, effect.Source, effect.Type, carrier.troop, effect.Damage)
+		} else if carrier.tower != nil && carrier.tower.HP > 0 {
+			ge.abilityDamageTower(effect.SourceID, effect.Source, effect.Type, carrier.tower, effect.Damage)
+		}
+	}
+
+	for i := 0; i < len(*carrier.effects); {
+		effect := &(*carrier.effects)[i]
+		if effect.Remaining <= 0 {
+			i++ // Lasts until depleted or cleared
+			continue
+		}
+
+		effect.Remaining--
+		if effect.Remaining == 0 {
+			ge.removeEffect(carrier, i, effectExpired)
+			continue
+		}
+		i++
+	}
+}
+
+// describeEffect renders an effect for event messages
+func describeEffect(effect StatusEffect) string {
+	switch effect.Type {
+	case EffectShield:
+		return fmt.Sprintf("a %d HP shield", effect.Shield)
+	case EffectPoison:
+		return fmt.Sprintf("poison (%d damage x%d)", effect.Damage, effect.Remaining)
+	case EffectSlow:
+		return fmt.Sprintf("slow (%d ATK)", effect.ATKMod)
+	case EffectRage:
+		return fmt.Sprintf("rage (+%d ATK)", effect.ATKMod)
+	}
+	return effect.Type
+}
@@ -3,114 +3,186 @@ package game
 
 import (
 	"fmt"
-	"math"
 	"math/rand"
+	"sync"
+	"sync/atomic"
 	"tcr-game/pkg/logger"
 	"time"
 )
 
-// GameEngine handles all game logic and state management
+// GameEngine handles all game logic and state management.
+// The live state is owned by the simulation loop goroutine; other goroutines
+// talk to it through queued commands and read immutable snapshots.
 type GameEngine struct {
 	gameState   *GameState
 	gameSpecs   *GameSpecs
 	eventQueue  []CombatAction
-	gameTimer   *time.Timer
 	isRunning   bool
 	eventChan   chan CombatAction
 	dataManager *DataManager
+	rng         *rand.Rand // Per-match random source, seeded from GameState.Seed
+	clock       Clock      // Real clock in production, FakeClock in tests and simulations
+	recorder    *ReplayRecorder
 	logger      *logger.Logger
+
+	// Simulation loop state (Enhanced mode, and the Simple mode turn clock)
+	tick        int                // Ticks elapsed since the match started
+	turnStart   int                // Tick the current Simple mode turn started on
+	bankTicks   map[string]int     // Chess clock of each player in ticks, Player.TimeBank shows it in seconds
+	commands    chan engineCommand // Inbox of queued player commands
+	scheduled   []scheduledAction  // Pending combat resolved by the loop
+	scheduleSeq int
+	deployments map[troopKey]int // Current deployment of each troop on the field
+	loopStarted atomic.Bool
+	loopDone    chan struct{}
+
+	stateMu  sync.RWMutex
+	snapshot *GameState // Copy of gameState published after every command and tick
 }
 
-// NewGameEngine creates a new game engine instance
-func NewGameEngine(player1, player2 *Player, gameMode string, specs *GameSpecs, dataManager *DataManager) *GameEngine {
+// NewGameEngine creates a new game engine instance playing the given ruleset.
+// The same seed plus the same sequence of actions always yields the same outcome.
+func NewGameEngine(player1, player2 *Player, rules Ruleset, specs *GameSpecs, dataManager *DataManager, seed int64) *GameEngine {
 	// Initialize players with random troops and leveled stats
-	initializePlayerForGame(player1, specs)
-	initializePlayerForGame(player2, specs)
+	initializePlayerForGame(player1, specs, rules)
+	initializePlayerForGame(player2, specs, rules)
 
 	player1.TroopsDeployedThisTurn = 0
 	player2.TroopsDeployedThisTurn = 0
 
 	gameState := &GameState{
 		ID:          generateGameID(),
-		GameMode:    gameMode,
+		GameMode:    rules.Mode,
 		Status:      StatusWaiting,
 		Player1:     *player1,
 		Player2:     *player2,
 		CurrentTurn: player1.ID,
-		TimeLeft:    GameDurationSeconds,
+		TimeLeft:    rules.DurationSeconds,
+		Rules:       rules,
 		StartTime:   time.Now(),
+		Seed:        seed,
 		TowersKilled: struct {
 			Player1 int `json:"player1"`
 			Player2 int `json:"player2"`
 		}{0, 0},
 	}
 
-	return &GameEngine{
+	ge := &GameEngine{
 		gameState:   gameState,
 		gameSpecs:   specs,
 		eventQueue:  make([]CombatAction, 0),
 		isRunning:   false,
 		eventChan:   make(chan CombatAction, 100),
 		dataManager: dataManager,
+		rng:         NewMatchRNG(seed),
+		clock:       NewRealClock(),
 		logger:      logger.Server,
+		commands:    make(chan engineCommand),
+		deployments: make(map[troopKey]int),
+		loopDone:    make(chan struct{}),
 	}
+	ge.publishSnapshot()
+
+	return ge
+}
+
+// SetClock replaces the engine clock. Must be called before StartGame.
+func (ge *GameEngine) SetClock(clock Clock) {
+	ge.clock = clock
+	ge.gameState.StartTime = clock.Now()
+	ge.publishSnapshot()
 }
 
 // StartGame begins the game based on mode
 func (ge *GameEngine) StartGame() error {
+	ge.recordHeader()
+
 	ge.gameState.Status = StatusActive
 	ge.isRunning = true
 
+	var err error
 	if ge.gameState.GameMode == ModeEnhanced {
-		return ge.startEnhancedMode()
+		err = ge.startEnhancedMode()
 	} else {
-		return ge.startSimpleMode()
+		err = ge.startSimpleMode()
 	}
+	if err != nil {
+		return err
+	}
+
+	// From here on every state change goes through the simulation loop
+	ge.publishSnapshot()
+	ge.loopStarted.Store(true)
+	go ge.runLoop()
+
+	return nil
 }
 
 // startSimpleMode initializes turn-based gameplay
 func (ge *GameEngine) startSimpleMode() error {
 	ge.logEvent("GAME_START", ge.gameState.CurrentTurn, map[string]interface{}{
-		"mode": "Simple TCR",
+		"mode":         "Simple TCR",
+		"ruleset":      ge.gameState.Rules.Name,
+		"turn_seconds": ge.gameState.Rules.TurnSeconds,
+		"bank_seconds": ge.gameState.Rules.BankSeconds,
 	})
+	ge.fillTimeBanks()
+	ge.refillActionPoints()
+	ge.resetTurnClock()
 	return nil
 }
 
 func (ge *GameEngine) startEnhancedMode() error {
-	// Start mana regeneration for both players
-	go ge.manaRegeneration()
-
-	ge.gameTimer = time.NewTimer(time.Duration(GameDurationSeconds) * time.Second)
-	go ge.gameTimeoutHandler()
+	ge.gameState.TimeLeft = ge.gameState.Rules.DurationSeconds
+	ge.gameState.Phase = PhaseRegular
+	reloadTowers(&ge.gameState.Player1, &ge.gameState.Player2)
 
-	ge.gameState.TimeLeft = GameDurationSeconds
+	// Combat, mana regeneration and the match clock all run on the simulation loop
 
 	ge.logEvent("GAME_START", "", map[string]interface{}{
-		"mode":       "Enhanced TCR",
-		"duration":   GameDurationSeconds,
-		"mana_regen": ManaRegenPerSecond,
-		"start_time": time.Now(),
+		"mode":        "Enhanced TCR",
+		"ruleset":     ge.gameState.Rules.Name,
+		"duration":    ge.gameState.Rules.DurationSeconds,
+		"mana_regen":  ge.gameState.Rules.ManaRegenPerSecond,
+		"double_mana": ge.gameState.Rules.DoubleManaSeconds,
+		"overtime":    ge.gameState.Rules.OvertimeSeconds,
+		"start_time":  ge.clock.Now(),
 	})
 
 	return nil
 }
 
-// SummonTroop handles troop summoning logic
-func (ge *GameEngine) SummonTroop(playerID string, troopName TroopType) (*CombatAction, error) {
+// SummonTroop handles troop summoning logic. The troop is deployed in lane;
+// support troops never enter the field and ignore it.
+func (ge *GameEngine) SummonTroop(playerID string, troopName TroopType, lane Lane) (*CombatAction, error) {
+	return ge.submit(func() (*CombatAction, error) {
+		action, err := ge.summonTroop(playerID, troopName, lane)
+		if err == nil {
+			ge.recordCommand(ReplayCommand{Type: ActionSummon, PlayerID: playerID, TroopName: troopName, Lane: lane})
+		}
+		return action, err
+	})
+}
+
+func (ge *GameEngine) summonTroop(playerID string, troopName TroopType, lane Lane) (*CombatAction, error) {
 	player := ge.getPlayer(playerID)
 	if player == nil {
 		return nil, fmt.Errorf("player not found")
 	}
 
+	if err := ge.checkPaused(); err != nil {
+		return nil, err
+	}
+
 	// Check if it's player's turn (Simple mode only)
 	if ge.gameState.GameMode == ModeSimple && ge.gameState.CurrentTurn != playerID {
 		return nil, fmt.Errorf("not your turn")
 	}
 
-	// Check deployment limit BEFORE any deployment
-	if ge.gameState.GameMode == ModeSimple {
-		if player.TroopsDeployedThisTurn >= 1 {
-			return nil, fmt.Errorf("cannot deploy more than one troop per turn in simple mode")
+	// Check deployment limit BEFORE any deployment, action points replace it
+	if ge.gameState.GameMode == ModeSimple && !ge.actionPointsOn() {
+		if player.TroopsDeployedThisTurn >= ge.gameState.Rules.CardsPerTurn {
+			return nil, fmt.Errorf("cannot play more than %d card(s) per turn in simple mode", ge.gameState.Rules.CardsPerTurn)
 		}
 	}
 
@@ -127,6 +199,34 @@ func (ge *GameEngine) SummonTroop(playerID string, troopName TroopType) (*Combat
 		return nil, fmt.Errorf("troop not available")
 	}
 
+	if !inHand(player, troopName) {
+		return nil, fmt.Errorf("%s is not in your hand", troopName)
+	}
+
+	if selectedTroop.IsSupport() {
+		lane = ""
+	} else if _, err := ParseLane(string(lane)); err != nil {
+		return nil, err
+	}
+
+	// Troops stay on the field in Enhanced mode, a live one can't be summoned again
+	if ge.gameState.GameMode == ModeEnhanced && selectedTroop.Deployed && selectedTroop.HP > 0 {
+		return nil, fmt.Errorf("%s is already on the field in the %s lane", troopName, selectedTroop.Lane)
+	}
+
+	// Check mana cost first so a rejected summon leaves the troop untouched (Enhanced mode only)
+	if ge.gameState.GameMode == ModeEnhanced {
+		if player.Mana < selectedTroop.MANA {
+			return nil, fmt.Errorf("insufficient mana: need %d, have %d", selectedTroop.MANA, player.Mana)
+		}
+		player.Mana -= selectedTroop.MANA
+	}
+
+	// A troop costs its mana in action points (Simple mode)
+	if err := ge.checkActionPoints(player, selectedTroop.MANA, string(troopName)); err != nil {
+		return nil, err
+	}
+
 	if ge.gameState.GameMode == ModeEnhanced {
 		baseSpec := ge.gameSpecs.TroopSpecs[troopName]
 		playerLevel := selectedTroop.Level
@@ -163,83 +263,50 @@ func (ge *GameEngine) SummonTroop(playerID string, troopName TroopType) (*Combat
 		})
 	}
 
-	// Check mana cost (Enhanced mode only)
-	if ge.gameState.GameMode == ModeEnhanced {
-		if player.Mana < selectedTroop.MANA {
-			return nil, fmt.Errorf("insufficient mana: need %d, have %d", selectedTroop.MANA, player.Mana)
-		}
-		player.Mana -= selectedTroop.MANA
-	}
-
 	// Increment deployment count for all troops
 	if ge.gameState.GameMode == ModeSimple {
 		player.TroopsDeployedThisTurn++
+		ge.spendActionPoints(player, selectedTroop.MANA)
 	}
 
-	// Handle Queen's healing ability
-	if troopName == Queen {
-		var lowestTower *Tower
-		lowestHP := math.MaxInt32
-
-		for i := range player.Towers {
-			if player.Towers[i].HP < lowestHP && player.Towers[i].HP > 0 {
-				lowestHP = player.Towers[i].HP
-				lowestTower = &player.Towers[i]
-			}
-		}
+	// A summoned troop enters the field without the effects of its last life
+	ge.clearEffects(troopCarrier(player, selectedTroop))
 
-		if lowestTower != nil {
-			healAmount := 300
-			if lowestTower.HP+healAmount > lowestTower.MaxHP {
-				healAmount = lowestTower.MaxHP - lowestTower.HP
-			}
+	// Support troops act once and leave, everything else stays on the field
+	selectedTroop.Deployed = !selectedTroop.IsSupport()
+	selectedTroop.Lane = lane
 
-			oldHP := lowestTower.HP
-			lowestTower.HP += healAmount
-
-			// Create heal event
-			healAction := CombatAction{
-				Type:       ActionHeal,
-				PlayerID:   playerID,
-				TroopName:  Queen,
-				TargetType: "tower",
-				TargetName: string(lowestTower.Name),
-				HealAmount: healAmount,
-				Timestamp:  time.Now(),
-				Data: map[string]interface{}{
-					"tower_hp":    lowestTower.HP,
-					"old_hp":      oldHP,
-					"heal_amount": healAmount,
-				},
-			}
+	// Enhanced mode troops walk down the lane before they can fight
+	if ge.gameState.GameMode == ModeEnhanced && selectedTroop.Deployed {
+		ge.sendTroop(playerID, selectedTroop)
+	}
 
-			ge.logEvent("HEAL", playerID, map[string]interface{}{
-				"troop":       Queen,
-				"target":      lowestTower.Name,
-				"heal_amount": healAmount,
-				"tower_hp":    lowestTower.HP,
-				"old_hp":      oldHP,
-			})
+	// The played card goes to the back of the queue and the next one is drawn
+	cycleCard(player, troopName)
 
-			ge.broadcastAction(healAction)
-		}
-	}
+	// Fire on-summon abilities, e.g. the Queen's heal
+	ge.triggerAbilities(TriggerOnSummon, abilityContext{playerID: playerID, troop: selectedTroop})
 
 	// Create summon event
 	action := CombatAction{
 		Type:      ActionSummon,
 		PlayerID:  playerID,
 		TroopName: troopName,
-		Timestamp: time.Now(),
+		Timestamp: ge.clock.Now(),
 		Data: map[string]interface{}{
 			"mana_left":                 player.Mana,
 			"troops_deployed_this_turn": player.TroopsDeployedThisTurn,
+			"action_points":             player.ActionPoints,
 			"troop_hp":                  selectedTroop.HP,
+			"lane":                      string(lane),
+			"travel_seconds":            float64(selectedTroop.Travel) / TickRate,
+			"next_card":                 string(player.NextCard),
 		},
 	}
 
 	ge.logEvent("SUMMON", playerID, map[string]interface{}{
 		"troop":                     troopName,
+		"lane":                      lane,
 		"troop_hp":                  selectedTroop.HP,
 		"mana_left":                 player.Mana,
 		"troops_deployed_this_turn": player.TroopsDeployedThisTurn,
@@ -247,31 +314,9 @@ func (ge *GameEngine) SummonTroop(playerID string, troopName TroopType) (*Combat
 
 	ge.updatePlayerInState(player)
 
-	if ge.gameState.GameMode == ModeEnhanced {
-		go ge.autoAttackSequence(playerID, troopName)
-	}
-
 	return &action, nil
 }
 
-// autoAttackSequence handles the full combat sequence for Enhanced mode
-func (ge *GameEngine) autoAttackSequence(playerID string, troopName TroopType) {
-	time.Sleep(500 * time.Millisecond)
-
-	attackAction := ge.executeAutoAttack(playerID, troopName)
-	if attackAction != nil {
-		ge.broadcastAction(*attackAction)
-
-		time.Sleep(2 * time.Second)
-		counterAction := ge.executeCounterAttack(playerID, troopName)
-		if counterAction != nil {
-			ge.broadcastAction(*counterAction)
-		}
-	}
-
-	time.Sleep(1 * time.Second)
-}
-
 func (ge *GameEngine) executeAutoAttack(playerID string, troopName TroopType) *CombatAction {
 	player := ge.getPlayer(playerID)
 	opponent := ge.getOpponent(playerID)
@@ -288,68 +333,45 @@ func (ge *GameEngine) executeAutoAttack(playerID string, troopName TroopType) *C
 		}
 	}
 
-	if attacker == nil || attacker.HP <= 0 {
+	if attacker == nil || attacker.HP <= 0 || !attacker.Deployed {
 		return nil
 	}
 
-	// Find target tower with updated rules
-	var targetTowerIndex int = -1
-
-	guardTowersAlive := 0
-	for i := range opponent.Towers {
-		if (opponent.Towers[i].Name == GuardTower1 || opponent.Towers[i].Name == GuardTower2) && opponent.Towers[i].HP > 0 {
-			guardTowersAlive++
-		}
+	if hasEffect(attacker.Effects, EffectStun) {
+		ge.logger.Debug("%s is stunned and skips its attack", troopName)
+		return nil
 	}
 
-	if guardTowersAlive == 0 {
-		for i := range opponent.Towers {
-			if opponent.Towers[i].Name == KingTower && opponent.Towers[i].HP > 0 {
-				targetTowerIndex = i
-				break
-			}
-		}
-	} else {
-		for i := range opponent.Towers {
-			if (opponent.Towers[i].Name == GuardTower1 || opponent.Towers[i].Name == GuardTower2) && opponent.Towers[i].HP > 0 {
-				if targetTowerIndex == -1 || opponent.Towers[i].HP < opponent.Towers[targetTowerIndex].HP {
-					targetTowerIndex = i
-				}
-			}
+	// Engage enemy troops in the lane before marching on its towers
+	if enemyTroop := laneTroopTarget(opponent, attacker.Lane); enemyTroop != nil {
+		action, err := ge.executeTroopAttack(player, opponent, attacker, string(enemyTroop.Name))
+		if err != nil {
+			return nil
 		}
+		return action
 	}
 
-	if targetTowerIndex == -1 {
+	// Then the lane's Guard Tower, and the King Tower once the lane is open
+	targetTower := laneTowerTarget(opponent, attacker.Lane)
+	if targetTower == nil {
 		return nil
 	}
 
-	var targetTower *Tower
-	if opponent.ID == ge.gameState.Player1.ID {
-		targetTower = &ge.gameState.Player1.Towers[targetTowerIndex]
-	} else {
-		targetTower = &ge.gameState.Player2.Towers[targetTowerIndex]
-	}
-
-	isCrit := false
-	attackDamage := attacker.ATK
-	if ge.gameState.GameMode == ModeEnhanced {
-		// Roll for crit chance
-		if rand.Float64() < attacker.CRIT {
-			isCrit = true
-			attackDamage = int(float64(attacker.ATK) * 1.5) // 1.5x damage on crit
-		}
-	}
+	attackDamage := effectiveATK(attacker.ATK, attacker.Effects)
+	attackDamage, isCrit := ge.rollCrit(attackDamage, attacker.CRIT)
 
-	damage := attackDamage - targetTower.DEF
+	damage := attackDamage - effectiveDEF(targetTower.DEF, targetTower.Effects)
 	if damage < 0 {
 		damage = 0
 	}
+	damage, absorbed := ge.absorbWithShield(towerCarrier(opponent, targetTower), damage)
 
 	oldHP := targetTower.HP
 	targetTower.HP -= damage
 	if targetTower.HP < 0 {
 		targetTower.HP = 0
 	}
+	ge.towerHit(opponent, targetTower)
 
 	if damage > 0 {
 		ge.awardEXPForDamage(playerID, damage, "tower")
@@ -365,7 +387,7 @@ func (ge *GameEngine) executeAutoAttack(playerID string, troopName TroopType) *C
 		expAction := CombatAction{
 			Type:      "EXP_GAINED",
 			PlayerID:  playerID,
-			Timestamp: time.Now(),
+			Timestamp: ge.clock.Now(),
 			Data: map[string]interface{}{
 				"amount": expGained,
 				"reason": fmt.Sprintf("destroying %s", targetTower.Name),
@@ -387,7 +409,7 @@ func (ge *GameEngine) executeAutoAttack(playerID string, troopName TroopType) *C
 			TargetType: "tower",
 			TargetName: string(targetTower.Name),
 			Damage:     damage,
-			Timestamp:  time.Now(),
+			Timestamp:  ge.clock.Now(),
 			Data: map[string]interface{}{
 				"destroyer": player.Username,
 				"owner":     opponent.Username,
@@ -398,6 +420,8 @@ func (ge *GameEngine) executeAutoAttack(playerID string, troopName TroopType) *C
 		ge.handleTowerDestroyed(opponent, targetTower)
 	}
 
+	ge.triggerAbilities(TriggerOnAttack, abilityContext{playerID: playerID, troop: attacker, target: targetTower, damage: damage})
+
 	// Create action
 	action := CombatAction{
 		Type:       ActionAttack,
@@ -407,138 +431,12 @@ func (ge *GameEngine) executeAutoAttack(playerID string, troopName TroopType) *C
 		TargetName: string(targetTower.Name),
 		Damage:     damage,
 		IsCrit:     isCrit, // ✅ NEW: Include crit info
-		Timestamp:  time.Now(),
+		Timestamp:  ge.clock.Now(),
 		Data: map[string]interface{}{
 			"target_hp": targetTower.HP,
 			"old_hp":    oldHP,
 			"tower_destroyed": towerDestroyed,
-		},
-	}
-
-	return &action
-}
-
-func (ge *GameEngine) executeCounterAttack(playerID string, troopName TroopType) *CombatAction {
-	player := ge.getPlayer(playerID)
-	opponent := ge.getOpponent(playerID)
-
-	if player == nil || opponent == nil {
-		return nil
-	}
-
-	var targetTroop *Troop
-	for i := range player.Troops {
-		if player.Troops[i].Name == troopName {
-			targetTroop = &player.Troops[i]
-			break
-		}
-	}
-
-	if targetTroop == nil {
-		return nil
-	}
-
-	// Special case for Queen - she can't be attacked
-	if targetTroop.Name == Queen {
-		return nil
-	}
-
-	// Check if troop is already destroyed
-	if targetTroop.HP <= 0 {
-		return nil
-	}
-
-	var attackingTower *Tower
-	for i := range opponent.Towers {
-		if opponent.Towers[i].HP > 0 {
-			attackingTower = &opponent.Towers[i]
-			break
-		}
-	}
-
-	if attackingTower == nil {
-		return nil
-	}
-
-	isCrit := false
-	attackDamage := attackingTower.ATK
-	if ge.gameState.GameMode == ModeEnhanced {
-		// Roll for crit chance
-		if rand.Float64() < attackingTower.CRIT {
-			isCrit = true
-			attackDamage = int(float64(attackingTower.ATK) * 1.5) // 1.5x damage on crit
-		}
-	}
-
-	damage := attackDamage - targetTroop.DEF
-	if damage < 0 {
-		damage = 0
-	}
-
-	oldHP := targetTroop.HP
-	targetTroop.HP -= damage
-	if targetTroop.HP < 0 {
-		targetTroop.HP = 0
-	}
-
-	if damage > 0 {
-		ge.awardEXPForDamage(opponent.ID, damage, "troop")
-	}
-
-	// Check troop destruction
-	if targetTroop.HP == 0 && oldHP > 0 {
-		ge.awardEXPForDestruction(opponent.ID, "troop", TroopType(targetTroop.Name))
-
-		ge.logEvent("TROOP_DESTROYED", "", map[string]interface{}{
-			"destroyer":    opponent.Username,
-			"troop_name":   targetTroop.Name,
-			"troop_owner":  player.Username,
-			"final_damage": damage,
-		})
-
-		destroyAction := CombatAction{
-			Type:       "TROOP_DESTROYED",
-			PlayerID:   opponent.ID,
-			TroopName:  TroopType(attackingTower.Name),
-			TargetType: "troop",
-			TargetName: string(troopName),
-			Damage:     damage,
-			Timestamp:  time.Now(),
-			Data: map[string]interface{}{
-				"destroyer": opponent.Username,
-				"owner":     player.Username,
-			},
-		}
-		ge.broadcastAction(destroyAction)
-	}
-
-	ge.updatePlayerInState(player)
-
-	ge.logEvent("COUNTER_ATTACK", opponent.ID, map[string]interface{}{
-		"attacker":     attackingTower.Name,
-		"attacker_atk": attackingTower.ATK,
-		"target":       troopName,
-		"target_def":   targetTroop.DEF,
-		"damage":       damage,
-		"target_hp":    targetTroop.HP,
-		"old_hp":       oldHP,
-		"is_crit":      isCrit,
-		"message":      fmt.Sprintf("%s counter-attacks %s for %d damage!", attackingTower.Name, troopName, damage),
-	})
-
-	action := CombatAction{
-		Type:       ActionAttack,
-		PlayerID:   opponent.ID,
-		TroopName:  TroopType(attackingTower.Name),
-		TargetType: "troop",
-		TargetName: string(troopName),
-		Damage:     damage,
-		IsCrit:     isCrit,
-		Timestamp:  time.Now(),
-		Data: map[string]interface{}{
-			"target_hp":  targetTroop.HP,
-			"old_hp":     oldHP,
-			"is_counter": true,
+			"shield_absorbed": absorbed,
 		},
 	}
 
@@ -593,6 +491,22 @@ func (ge *GameEngine) awardEXPForDestruction(playerID string, targetType string,
 
 // ExecuteAttack handles manual combat between troops and towers
 func (ge *GameEngine) ExecuteAttack(playerID string, attackerName TroopType, targetType, targetName string) (*CombatAction, error) {
+	return ge.submit(func() (*CombatAction, error) {
+		action, err := ge.executeAttack(playerID, attackerName, targetType, targetName)
+		if err == nil {
+			ge.recordCommand(ReplayCommand{
+				Type:       ActionAttack,
+				PlayerID:   playerID,
+				TroopName:  attackerName,
+				TargetType: targetType,
+				TargetName: targetName,
+			})
+		}
+		return action, err
+	})
+}
+
+func (ge *GameEngine) executeAttack(playerID string, attackerName TroopType, targetType, targetName string) (*CombatAction, error) {
 	player := ge.getPlayer(playerID)
 	opponent := ge.getOpponent(playerID)
 
@@ -600,6 +514,10 @@ func (ge *GameEngine) ExecuteAttack(playerID string, attackerName TroopType, tar
 		return nil, fmt.Errorf("invalid players")
 	}
 
+	if err := ge.checkPaused(); err != nil {
+		return nil, err
+	}
+
 	var attacker *Troop
 	for i := range player.Troops {
 		if player.Troops[i].Name == attackerName {
@@ -616,10 +534,49 @@ func (ge *GameEngine) ExecuteAttack(playerID string, attackerName TroopType, tar
 		return nil, fmt.Errorf("troop is destroyed and cannot attack")
 	}
 
-	if ge.gameState.GameMode == ModeSimple {
-		if err := ge.validateAttackTargetUpdated(opponent, targetType, targetName); err != nil {
-			return nil, err
+	if attacker.IsSupport() {
+		return nil, fmt.Errorf("%s cannot attack", attacker.Name)
+	}
+
+	if !attacker.Deployed {
+		return nil, fmt.Errorf("%s must be deployed in a lane before it can attack", attacker.Name)
+	}
+
+	if attacker.Travel > 0 {
+		return nil, fmt.Errorf("%s is still walking the %s lane and cannot attack yet", attacker.Name, attacker.Lane)
+	}
+
+	// Troops on the field attack on their own at their hit speed (Enhanced mode)
+	if ge.gameState.GameMode == ModeEnhanced {
+		return nil, fmt.Errorf("%s attacks on its own every %.1fs in enhanced mode", attacker.Name, attacker.HitSpeed)
+	}
+
+	if hasEffect(attacker.Effects, EffectStun) {
+		return nil, fmt.Errorf("%s is stunned and cannot attack", attacker.Name)
+	}
+
+	// Attacks are played on your own turn and cost action points (Simple mode)
+	if ge.gameState.GameMode == ModeSimple && ge.gameState.CurrentTurn != playerID {
+		return nil, fmt.Errorf("not your turn")
+	}
+	if err := ge.checkActionPoints(player, AttackActionCost, "an attack"); err != nil {
+		return nil, err
+	}
+
+	switch targetType {
+	case "tower":
+	case "troop":
+		action, err := ge.executeTroopAttack(player, opponent, attacker, targetName)
+		if err == nil {
+			ge.spendActionPoints(player, AttackActionCost)
 		}
+		return action, err
+	default:
+		return nil, fmt.Errorf("invalid target type: %s", targetType)
+	}
+
+	if err := validateLaneTarget(opponent, attacker, targetType, targetName); err != nil {
+		return nil, err
 	}
 
 	var targetTower *Tower
@@ -642,26 +599,21 @@ func (ge *GameEngine) ExecuteAttack(playerID string, attackerName TroopType, tar
 		}
 	}
 
-	isCrit := false
-	attackDamage := attacker.ATK
-	if ge.gameState.GameMode == ModeEnhanced {
-		// Roll for crit chance
-		if rand.Float64() < attacker.CRIT {
-			isCrit = true
-			attackDamage = int(float64(attacker.ATK) * 1.5) // 1.5x damage on crit
-		}
-	}
+	attackDamage := effectiveATK(attacker.ATK, attacker.Effects)
+	attackDamage, isCrit := ge.rollCrit(attackDamage, attacker.CRIT)
 
-	damage := attackDamage - targetTower.DEF
+	damage := attackDamage - effectiveDEF(targetTower.DEF, targetTower.Effects)
 	if damage < 0 {
 		damage = 0
 	}
+	damage, absorbed := ge.absorbWithShield(towerCarrier(opponent, targetTower), damage)
 
 	oldHP := targetTower.HP
 	targetTower.HP -= damage
 	if targetTower.HP < 0 {
 		targetTower.HP = 0
 	}
+	ge.towerHit(opponent, targetTower)
 
 	// Award EXP for damage
 	if damage > 0 {
@@ -676,6 +628,8 @@ func (ge *GameEngine) ExecuteAttack(playerID string, attackerName TroopType, tar
 		ge.handleTowerDestroyed(opponent, targetTower)
 	}
 
+	ge.triggerAbilities(TriggerOnAttack, abilityContext{playerID: playerID, troop: attacker, target: targetTower, damage: damage})
+
 	action := CombatAction{
 		Type:       ActionAttack,
 		PlayerID:   playerID,
@@ -684,11 +638,12 @@ func (ge *GameEngine) ExecuteAttack(playerID string, attackerName TroopType, tar
 		TargetName: targetName,
 		Damage:     damage,
 		IsCrit:     isCrit,
-		Timestamp:  time.Now(),
+		Timestamp:  ge.clock.Now(),
 		Data: map[string]interface{}{
 			"target_hp": targetTower.HP,
 			"old_hp":    oldHP,
 			"tower_destroyed": towerDestroyed,
+			"shield_absorbed": absorbed,
 		},
 	}
 
@@ -696,97 +651,127 @@ func (ge *GameEngine) ExecuteAttack(playerID string, attackerName TroopType, tar
 
 	if ge.gameState.GameMode == ModeSimple {
 		// No automatic turn ending in Simple mode
+		ge.spendActionPoints(player, AttackActionCost)
 	}
 
 	if ge.checkWinConditions() {
-		ge.endGame()
+		ge.endGame(EndKingTowerDestroyed)
 	}
 
 	return &action, nil
 }
 
-// validateAttackTargetUpdated with new targeting rules
-func (ge *GameEngine) validateAttackTargetUpdated(opponent *Player, targetType, targetName string) error {
-	if targetType != "tower" {
-		return nil
-	}
-
-	if targetName == "King Tower" {
-		guardTowersAlive := 0
-		for _, tower := range opponent.Towers {
-			if (tower.Name == GuardTower1 || tower.Name == GuardTower2) && tower.HP > 0 {
-				guardTowersAlive++
-			}
+// executeTroopAttack resolves a troop attacking a deployed enemy troop
+func (ge *GameEngine) executeTroopAttack(player, opponent *Player, attacker *Troop, targetName string) (*CombatAction, error) {
+	var targetTroop *Troop
+	for i := range opponent.Troops {
+		if string(opponent.Troops[i].Name) == targetName {
+			targetTroop = &opponent.Troops[i]
+			break
 		}
+	}
 
-		if guardTowersAlive == 2 {
-			return fmt.Errorf("must destroy at least one Guard Tower before attacking King Tower")
-		}
+	if targetTroop == nil {
+		return nil, fmt.Errorf("target troop not found")
+	}
+	if targetTroop.IsSupport() {
+		return nil, fmt.Errorf("%s cannot be attacked", targetTroop.Name)
+	}
+	if !targetTroop.Deployed || targetTroop.HP <= 0 {
+		return nil, fmt.Errorf("target troop is not on the field")
+	}
+	if !engaged(targetTroop) {
+		return nil, fmt.Errorf("%s is still on its way down the %s lane", targetTroop.Name, targetTroop.Lane)
+	}
+	if targetTroop.Lane != attacker.Lane {
+		return nil, fmt.Errorf("%s is in the %s lane and cannot reach %s in the %s lane",
+			attacker.Name, attacker.Lane, targetTroop.Name, targetTroop.Lane)
 	}
 
-	return nil
-}
+	attackDamage := effectiveATK(attacker.ATK, attacker.Effects)
+	attackDamage, isCrit := ge.rollCrit(attackDamage, attacker.CRIT)
 
-// handleQueenSummon handles Queen's special healing ability
-func (ge *GameEngine) handleQueenSummon(playerID string) (*CombatAction, error) {
-	player := ge.getPlayer(playerID)
-	if player == nil {
-		return nil, fmt.Errorf("player not found")
+	damage := attackDamage - effectiveDEF(targetTroop.DEF, targetTroop.Effects)
+	if damage < 0 {
+		damage = 0
 	}
+	damage, absorbed := ge.absorbWithShield(troopCarrier(opponent, targetTroop), damage)
 
-	var lowestTower *Tower
-	lowestHP := math.MaxInt32
-
-	for i := range player.Towers {
-		if player.Towers[i].HP < lowestHP && player.Towers[i].HP > 0 {
-			lowestHP = player.Towers[i].HP
-			lowestTower = &player.Towers[i]
-		}
+	oldHP := targetTroop.HP
+	targetTroop.HP -= damage
+	if targetTroop.HP < 0 {
+		targetTroop.HP = 0
 	}
 
-	if lowestTower == nil {
-		return nil, fmt.Errorf("no towers to heal")
+	if damage > 0 {
+		ge.awardEXPForDamage(player.ID, damage, "troop")
 	}
 
-	healAmount := 300
-	if lowestTower.HP+healAmount > lowestTower.MaxHP {
-		healAmount = lowestTower.MaxHP - lowestTower.HP
+	troopDestroyed := false
+	if targetTroop.HP == 0 && oldHP > 0 {
+		troopDestroyed = true
+		ge.awardEXPForDestruction(player.ID, "troop", targetTroop.Name)
+
+		ge.logEvent("TROOP_DESTROYED", "", map[string]interface{}{
+			"destroyer":    player.Username,
+			"troop_name":   targetTroop.Name,
+			"troop_owner":  opponent.Username,
+			"final_damage": damage,
+		})
+
+		ge.broadcastAction(CombatAction{
+			Type:       "TROOP_DESTROYED",
+			PlayerID:   player.ID,
+			TroopName:  attacker.Name,
+			TargetType: "troop",
+			TargetName: string(targetTroop.Name),
+			Damage:     damage,
+			Timestamp:  ge.clock.Now(),
+			Data: map[string]interface{}{
+				"destroyer": player.Username,
+				"owner":     opponent.Username,
+			},
+		})
+
+		ge.handleTroopDestroyed(opponent, targetTroop)
 	}
 
-	oldHP := lowestTower.HP
-	lowestTower.HP += healAmount
+	ge.triggerAbilities(TriggerOnAttack, abilityContext{playerID: player.ID, troop: attacker, targetTroop: targetTroop, damage: damage})
 
 	action := CombatAction{
-		Type:       ActionHeal,
-		PlayerID:   playerID,
-		TroopName:  Queen,
-		TargetType: "tower",
-		TargetName: string(lowestTower.Name),
-		HealAmount: healAmount,
-		Timestamp:  time.Now(),
+		Type:       ActionAttack,
+		PlayerID:   player.ID,
+		TroopName:  attacker.Name,
+		TargetType: "troop",
+		TargetName: string(targetTroop.Name),
+		Damage:     damage,
+		IsCrit:     isCrit,
+		Timestamp:  ge.clock.Now(),
 		Data: map[string]interface{}{
-			"tower_hp":     lowestTower.HP,
-			"old_hp":       oldHP,
-			"heal_amount":  healAmount,
-			"mana_left":    player.Mana,
+			"target_hp":       targetTroop.HP,
+			"old_hp":          oldHP,
+			"troop_destroyed": troopDestroyed,
+			"shield_absorbed": absorbed,
 		},
 	}
 
-	ge.logEvent("HEAL", playerID, map[string]interface{}{
-		"troop":       Queen,
-		"target":      lowestTower.Name,
-		"heal_amount": healAmount,
-		"tower_hp":    lowestTower.HP,
-		"old_hp":      oldHP,
-	})
-
-	ge.updatePlayerInState(player)
 	return &action, nil
 }
 
+// rollCrit rolls for a crit at the given chance and scales the damage by the
+// ruleset's crit multiplier. Rulesets without crits never roll.
+func (ge *GameEngine) rollCrit(damage int, chance float64) (int, bool) {
+	multiplier := ge.gameState.Rules.CritMultiplier
+	if multiplier <= 1 || ge.rng.Float64() >= chance {
+		return damage, false
+	}
+	return int(float64(damage) * multiplier), true
+}
+
 // handleTowerDestroyed handles tower destruction logic
 func (ge *GameEngine) handleTowerDestroyed(player *Player, tower *Tower) {
 	tower.IsActive = false
+	ge.clearEffects(towerCarrier(player, tower))
 
 	if player.ID == ge.gameState.Player1.ID {
 		ge.gameState.TowersKilled.Player1++
@@ -798,16 +783,23 @@ func (ge *GameEngine) handleTowerDestroyed(player *Player, tower *Tower) {
 		"owner":      player.Username,
 		"tower_name": tower.Name,
 	})
-}
 
-// gameTimeoutHandler handles game timeout for Enhanced mode
-func (ge *GameEngine) gameTimeoutHandler() {
-	<-ge.gameTimer.C
-	if ge.isRunning {
-		ge.endGameByTimeout()
+	// Losing a Guard Tower wakes the King
+	if tower.Name != KingTower {
+		ge.activateKingTower(player, KingActivatedGuardDestroyed)
 	}
 }
 
+// handleTroopDestroyed takes a destroyed troop off the field and fires its on-death abilities
+func (ge *GameEngine) handleTroopDestroyed(owner *Player, troop *Troop) {
+	troop.Deployed = false
+	troop.Lane = ""
+	troop.Travel = 0
+	ge.clearEffects(troopCarrier(owner, troop))
+
+	ge.triggerAbilities(TriggerOnDeath, abilityContext{playerID: owner.ID, troop: troop})
+}
+
 // checkWinConditions checks if game should end
 func (ge *GameEngine) checkWinConditions() bool {
 	// Check Player1's King Tower
@@ -816,7 +808,7 @@ func (ge *GameEngine) checkWinConditions() bool {
 			ge.gameState.Winner = ge.gameState.Player2.ID
 			ge.logger.Info("Player2 wins - Player1's King Tower destroyed")
 			ge.awardGameEndEXP()
-			ge.endGame()
+			ge.endGame(EndKingTowerDestroyed)
 			return true
 		}
 	}
@@ -827,16 +819,27 @@ func (ge *GameEngine) checkWinConditions() bool {
 			ge.gameState.Winner = ge.gameState.Player1.ID
 			ge.logger.Info("Player1 wins - Player2's King Tower destroyed")
 			ge.awardGameEndEXP()
-			ge.endGame()
+			ge.endGame(EndKingTowerDestroyed)
 			return true
 		}
 	}
 
-	return false
+	return ge.checkSuddenDeath()
 }
 
 // EndTurn handles ending a player's turn (Simple mode only)
 func (ge *GameEngine) EndTurn(playerID string) error {
+	_, err := ge.submit(func() (*CombatAction, error) {
+		err := ge.endTurn(playerID)
+		if err == nil {
+			ge.recordCommand(ReplayCommand{Type: ActionEndTurn, PlayerID: playerID})
+		}
+		return nil, err
+	})
+	return err
+}
+
+func (ge *GameEngine) endTurn(playerID string) error {
 	if ge.gameState.GameMode != ModeSimple {
 		return fmt.Errorf("end turn only available in Simple mode")
 	}
@@ -845,9 +848,26 @@ func (ge *GameEngine) EndTurn(playerID string) error {
 		return fmt.Errorf("not your turn")
 	}
 
+	if err := ge.checkPaused(); err != nil {
+		return err
+	}
+
+	// Ending the turn by hand breaks a run of timeouts
+	ge.getPlayer(playerID).TurnTimeouts = 0
+
+	ge.finishTurn(playerID, TurnEndedByPlayer)
+	return nil
+}
+
+// finishTurn hands the turn to the opponent. reason says whether the player
+// ended it or the turn clock ran out.
+func (ge *GameEngine) finishTurn(playerID, reason string) {
 	// Store old turn for logging
 	oldTurn := ge.gameState.CurrentTurn
 
+	// A finished turn costs its thinking time and earns the chess clock increment
+	ge.chargeTimeBank(ge.getPlayer(playerID))
+
 	// Switch turn first
 	ge.switchTurn()
 
@@ -855,10 +875,13 @@ func (ge *GameEngine) EndTurn(playerID string) error {
 	action := CombatAction{
 		Type:      "TURN_END",
 		PlayerID:  playerID,
-		Timestamp: time.Now(),
+		Timestamp: ge.clock.Now(),
 		Data: map[string]interface{}{
-			"next_turn": ge.gameState.CurrentTurn,
-			"old_turn":  oldTurn,
+			"next_turn":      ge.gameState.CurrentTurn,
+			"old_turn":       oldTurn,
+			"reason":         reason,
+			"turn_time_left": ge.gameState.TurnTimeLeft,
+			"time_bank":      ge.getPlayer(playerID).TimeBank,
 		},
 	}
 
@@ -866,48 +889,70 @@ func (ge *GameEngine) EndTurn(playerID string) error {
 	ge.logEvent("TURN_END", playerID, map[string]interface{}{
 		"next_turn": ge.gameState.CurrentTurn,
 		"old_turn":  oldTurn,
+		"reason":    reason,
 	})
 
 	// Broadcast the action immediately
 	ge.broadcastAction(action)
 
+	// Effects on the side that just played count down one turn, poison hits
+	ge.tickEffects(ge.getPlayer(playerID))
+	if ge.isRunning {
+		ge.checkWinConditions()
+	}
+
 	// Update game state
 	ge.updatePlayerInState(&ge.gameState.Player1)
 	ge.updatePlayerInState(&ge.gameState.Player2)
+}
+
+// Surrender ends the game in favour of the opponent
+func (ge *GameEngine) Surrender(playerID string) error {
+	_, err := ge.submit(func() (*CombatAction, error) {
+		err := ge.surrender(playerID)
+		if err == nil {
+			ge.recordCommand(ReplayCommand{Type: ActionSurrender, PlayerID: playerID})
+		}
+		return nil, err
+	})
+	return err
+}
 
+func (ge *GameEngine) surrender(playerID string) error {
+	ge.forfeit(playerID, EndSurrender)
 	return nil
 }
 
-func (ge *GameEngine) Surrender(playerID string) error {
-	// Determine winner (opponent of surrendering player)
+// forfeit ends the game in favour of the opponent of playerID. reason is
+// reported in the GAME_END event.
+func (ge *GameEngine) forfeit(playerID, reason string) {
+	// Determine winner (opponent of the forfeiting player)
 	if playerID == ge.gameState.Player1.ID {
 		ge.gameState.Winner = ge.gameState.Player2.ID
 	} else {
 		ge.gameState.Winner = ge.gameState.Player1.ID
 	}
 
-	// Award EXP for surrender
+	// Award EXP for the forfeit
 	ge.awardGameEndEXP()
 
-	ge.endGame()
+	ge.endGame(reason)
 
-	ge.logEvent("SURRENDER", playerID, map[string]interface{}{
+	ge.logEvent("FORFEIT", playerID, map[string]interface{}{
 		"winner": ge.gameState.Winner,
-		"reason": "opponent_surrendered",
+		"reason": reason,
 	})
-
-	return nil
 }
 
 func (ge *GameEngine) awardGameEndEXP() {
 	var winnerEXP, loserEXP int
 
 	if ge.gameState.Winner == "draw" {
-		winnerEXP = DrawEXP
-		loserEXP = DrawEXP
+		winnerEXP = ge.gameState.Rules.DrawEXP
+		loserEXP = ge.gameState.Rules.DrawEXP
 	} else {
-		winnerEXP = WinEXP
-		// loserEXP = LoseEXP
+		winnerEXP = ge.gameState.Rules.WinEXP
+		// loserEXP = ge.gameState.Rules.LoseEXP
 	}
 
 	// Award EXP to both players
@@ -988,49 +1033,54 @@ func (ge *GameEngine) endGameByTimeout() {
 		}
 	}
 
+	// Tied on towers lost: play overtime before the rest of the tiebreaker chain
+	if player1KingAlive && player2KingAlive && player1TowersDestroyed == player2TowersDestroyed {
+		if ge.startOvertime() {
+			ge.logger.Info("Tied on towers lost (%d each) - overtime", player1TowersDestroyed)
+			return
+		}
+	}
+
+	var result tiebreakResult
 	if !player1KingAlive && player2KingAlive {
-		ge.gameState.Winner = ge.gameState.Player2.ID
+		result = tiebreakResult{winner: ge.gameState.Player2.ID, reason: EndKingTowerDestroyed}
 		ge.logger.Info("Player2 wins - Player1's King Tower destroyed")
 	} else if !player2KingAlive && player1KingAlive {
-		ge.gameState.Winner = ge.gameState.Player1.ID
+		result = tiebreakResult{winner: ge.gameState.Player1.ID, reason: EndKingTowerDestroyed}
 		ge.logger.Info("Player1 wins - Player2's King Tower destroyed")
 	} else if !player1KingAlive && !player2KingAlive {
-		ge.gameState.Winner = "draw"
+		result = tiebreakResult{winner: "draw", reason: EndDraw}
 		ge.logger.Info("Draw - Both King Towers destroyed")
 	} else {
-		// Both King Towers alive - compare tower destruction count
-		if player1TowersDestroyed < player2TowersDestroyed {
-			ge.gameState.Winner = ge.gameState.Player1.ID
-			ge.logger.Info("Player1 wins - lost fewer towers (%d vs %d)", player1TowersDestroyed, player2TowersDestroyed)
-		} else if player2TowersDestroyed < player1TowersDestroyed {
-			ge.gameState.Winner = ge.gameState.Player2.ID
-			ge.logger.Info("Player2 wins - lost fewer towers (%d vs %d)", player2TowersDestroyed, player1TowersDestroyed)
-		} else {
-			ge.gameState.Winner = "draw"
-			ge.logger.Info("Draw - same towers destroyed (%d vs %d)", player1TowersDestroyed, player2TowersDestroyed)
-		}
+		// Both King Towers alive - run the tiebreaker chain
+		result = ge.breakTie()
+		ge.logger.Info("Timeout decided by %s: winner %s (%.1f vs %.1f)",
+			result.reason, result.winner, result.player1, result.player2)
 	}
+	ge.gameState.Winner = result.winner
 
 	ge.awardGameEndEXP()
 
 	gameEndEvent := CombatAction{
 		Type:      "GAME_END",
 		PlayerID:  "",
-		Timestamp: time.Now(),
+		Timestamp: ge.clock.Now(),
 		Data: map[string]interface{}{
 			"winner":         ge.gameState.Winner,
-			"reason":         "timeout",
+			"reason":         result.reason,
+			"player1_score":  result.player1,
+			"player2_score":  result.player2,
 			"player1_towers": player1TowersDestroyed,
 			"player2_towers": player2TowersDestroyed,
 		},
 	}
-	ge.eventChan <- gameEndEvent
+	ge.broadcastAction(gameEndEvent)
 
-	ge.endGame()
+	ge.endGame(result.reason)
 }
 
-// endGame handles game conclusion
-func (ge *GameEngine) endGame() {
+// endGame handles game conclusion. reason is reported in the GAME_END event.
+func (ge *GameEngine) endGame(reason string) {
 	if !ge.isRunning {
 		return // Game already ended
 	}
@@ -1038,18 +1088,14 @@ func (ge *GameEngine) endGame() {
 	ge.isRunning = false
 	ge.gameState.Status = StatusFinished
 
-	if ge.gameTimer != nil {
-		ge.gameTimer.Stop()
-	}
-
 	// Create and broadcast game end event
 	gameEndEvent := CombatAction{
 		Type:      "GAME_END",
 		PlayerID:  ge.gameState.Winner,
-		Timestamp: time.Now(),
+		Timestamp: ge.clock.Now(),
 		Data: map[string]interface{}{
 			"winner":         ge.gameState.Winner,
-			"reason":         "king_tower_destroyed",
+			"reason":         reason,
 			"towers_p1":      ge.gameState.TowersKilled.Player1,
 			"towers_p2":      ge.gameState.TowersKilled.Player2,
 			"player1_exp":    ge.gameState.Player1.EXP,
@@ -1074,6 +1120,7 @@ func (ge *GameEngine) switchTurn() {
 		// Reset troop deployment counters
 		ge.gameState.Player1.TroopsDeployedThisTurn = 0
 		ge.gameState.Player2.TroopsDeployedThisTurn = 0
+		ge.refillActionPoints()
 	}
 
 	// Store old turn for logging
@@ -1086,63 +1133,63 @@ func (ge *GameEngine) switchTurn() {
 		ge.gameState.CurrentTurn = ge.gameState.Player1.ID
 	}
 
+	// The next player gets a full turn clock
+	ge.resetTurnClock()
+
 	// Log the turn change
 	ge.logger.Info("Turn switched from %s to %s", oldTurn, ge.gameState.CurrentTurn)
 }
 
-// manaRegeneration handles mana regeneration for Enhanced mode
-func (ge *GameEngine) manaRegeneration() {
-	ticker := time.NewTicker(1 * time.Second)
-	defer ticker.Stop()
-
-	for ge.isRunning {
-		select {
-		case <-ticker.C:
-			oldMana1 := ge.gameState.Player1.Mana
-			oldMana2 := ge.gameState.Player2.Mana
-
-			if ge.gameState.Player1.Mana < MaxMana {
-				ge.gameState.Player1.Mana += ManaRegenPerSecond
-				if ge.gameState.Player1.Mana > MaxMana {
-					ge.gameState.Player1.Mana = MaxMana
-				}
-			}
+// regenerateMana runs once per simulated second: regenerates mana,
+// advances the match clock and phase and ends the game on timeout (Enhanced mode)
+func (ge *GameEngine) regenerateMana() {
+	oldMana1 := ge.gameState.Player1.Mana
+	oldMana2 := ge.gameState.Player2.Mana
+	regen := ge.gameState.Rules.ManaRegenRate(ge.gameState.Phase)
+	maxMana := ge.gameState.Rules.MaxMana
+
+	if ge.gameState.Player1.Mana < maxMana {
+		ge.gameState.Player1.Mana += regen
+		if ge.gameState.Player1.Mana > maxMana {
+			ge.gameState.Player1.Mana = maxMana
+		}
+	}
 
-			if ge.gameState.Player2.Mana < MaxMana {
-				ge.gameState.Player2.Mana += ManaRegenPerSecond
-				if ge.gameState.Player2.Mana > MaxMana {
-					ge.gameState.Player2.Mana = MaxMana
-				}
-			}
+	if ge.gameState.Player2.Mana < maxMana {
+		ge.gameState.Player2.Mana += regen
+		if ge.gameState.Player2.Mana > maxMana {
+			ge.gameState.Player2.Mana = maxMana
+		}
+	}
 
-			ge.gameState.TimeLeft--
-
-			// Send mana update
-			if oldMana1 != ge.gameState.Player1.Mana || oldMana2 != ge.gameState.Player2.Mana {
-				manaUpdateEvent := CombatAction{
-					Type:      "MANA_UPDATE",
-					PlayerID:  "",
-					Timestamp: time.Now(),
-					Data: map[string]interface{}{
-						"player1_mana": ge.gameState.Player1.Mana,
-						"player2_mana": ge.gameState.Player2.Mana,
-						"time_left":    ge.gameState.TimeLeft,
-					},
-				}
-				ge.eventChan <- manaUpdateEvent
-			}
+	ge.gameState.TimeLeft--
+	ge.advancePhase()
 
-			if ge.gameState.TimeLeft <= 0 {
-				ge.logger.Info("Time's up! Ending game by timeout...")
-				go ge.endGameByTimeout() // Use goroutine to prevent blocking
-				return
-			}
+	// Send mana update
+	if oldMana1 != ge.gameState.Player1.Mana || oldMana2 != ge.gameState.Player2.Mana {
+		manaUpdateEvent := CombatAction{
+			Type:      "MANA_UPDATE",
+			PlayerID:  "",
+			Timestamp: ge.clock.Now(),
+			Data: map[string]interface{}{
+				"player1_mana": ge.gameState.Player1.Mana,
+				"player2_mana": ge.gameState.Player2.Mana,
+				"time_left":    ge.gameState.TimeLeft,
+			},
 		}
+		ge.broadcastAction(manaUpdateEvent)
+	}
+
+	if ge.gameState.TimeLeft <= 0 {
+		ge.logger.Info("Time's up! Ending game by timeout...")
+		ge.endGameByTimeout()
 	}
 }
 
 // broadcastAction sends action to event channel for server broadcasting
 func (ge *GameEngine) broadcastAction(action CombatAction) {
+	ge.recordEvent(action)
+
 	select {
 	case ge.eventChan <- action:
 		// Successfully sent
@@ -1183,15 +1230,27 @@ func (ge *GameEngine) logEvent(eventType, playerID string, data map[string]inter
 	action := CombatAction{
 		Type:      eventType,
 		PlayerID:  playerID,
-		Timestamp: time.Now(),
+		Timestamp: ge.clock.Now(),
 		Data:      data,
 	}
 	ge.eventQueue = append(ge.eventQueue, action)
 }
 
-// GetGameState returns current game state
+// GetGameState returns a snapshot of the game state as of the last command or tick.
+// The snapshot is a private copy; changing it does not affect the match.
 func (ge *GameEngine) GetGameState() *GameState {
-	return ge.gameState
+	ge.stateMu.RLock()
+	defer ge.stateMu.RUnlock()
+	return ge.snapshot.Clone()
+}
+
+// publishSnapshot copies the live state for readers on other goroutines
+func (ge *GameEngine) publishSnapshot() {
+	snapshot := ge.gameState.Clone()
+
+	ge.stateMu.Lock()
+	ge.snapshot = snapshot
+	ge.stateMu.Unlock()
 }
 
 // GetEventChannel returns the event channel for broadcasting
@@ -1201,16 +1260,32 @@ func (ge *GameEngine) GetEventChannel() <-chan CombatAction {
 
 // IsRunning returns if game is currently active
 func (ge *GameEngine) IsRunning() bool {
-	return ge.isRunning
+	ge.stateMu.RLock()
+	defer ge.stateMu.RUnlock()
+	return ge.snapshot.Status == StatusActive
 }
 
-// generateGameID creates a unique game ID
+// gameSeq numbers the games created by this process
+var gameSeq atomic.Uint64
+
+// generateGameID creates a unique game ID: the nanosecond start time keeps
+// IDs apart across server restarts, the sequence number within one process
 func generateGameID() string {
-	return fmt.Sprintf("game_%d_%d", time.Now().Unix(), rand.Intn(1000))
+	return fmt.Sprintf("game_%d_%d", time.Now().UnixNano(), gameSeq.Add(1))
 }
 
 // StopGame stops the game and cleans up resources
 func (ge *GameEngine) StopGame() {
+	ge.submit(func() (*CombatAction, error) {
+		if ge.isRunning {
+			ge.recordCommand(ReplayCommand{Type: ReplayCommandStop})
+		}
+		ge.stopGame()
+		return nil, nil
+	})
+}
+
+func (ge *GameEngine) stopGame() {
 	if !ge.isRunning {
 		return // Game already stopped
 	}
@@ -1218,21 +1293,17 @@ func (ge *GameEngine) StopGame() {
 	ge.isRunning = false
 	ge.gameState.Status = StatusFinished
 
-	if ge.gameTimer != nil {
-		ge.gameTimer.Stop()
-	}
-
 	// Send final game end event
 	gameEndEvent := CombatAction{
 		Type:      "GAME_END",
 		PlayerID:  "",
-		Timestamp: time.Now(),
+		Timestamp: ge.clock.Now(),
 		Data: map[string]interface{}{
 			"winner": ge.gameState.Winner,
 			"reason": "game_stopped",
 		},
 	}
-	ge.eventChan <- gameEndEvent
+	ge.broadcastAction(gameEndEvent)
 
 	ge.logEvent("GAME_STOPPED", "", map[string]interface{}{
 		"towers_p1": ge.gameState.TowersKilled.Player1,
@@ -0,0 +1,125 @@
+// Package game implements troops that walk their lane and stay on the field (Enhanced mode)
+package game
+
+import "fmt"
+
+// troopKey identifies a player's troop across its deployments
+type troopKey struct {
+	playerID string
+	troop    TroopType
+}
+
+// ValidateTroops checks every fighting troop has a usable hit and move speed
+func ValidateTroops(troopSpecs map[TroopType]TroopSpec) error {
+	for name, spec := range troopSpecs {
+		if spec.HP <= 0 {
+			continue
+		}
+		if spec.HitSpeed <= 0 {
+			return fmt.Errorf("troop %s: hit_speed must be positive", name)
+		}
+		if spec.MoveSpeed <= 0 {
+			return fmt.Errorf("troop %s: move_speed must be positive", name)
+		}
+	}
+	return nil
+}
+
+// engaged reports whether the troop has reached the fight: it can attack and
+// be attacked. A troop still walking its lane is neither.
+func engaged(troop *Troop) bool {
+	return troop.Deployed && troop.HP > 0 && troop.Travel == 0
+}
+
+// travelTicks is how long the troop takes to walk its lane to the fight
+func travelTicks(troop *Troop) int {
+	return secondsToTicks(LaneLength / troop.MoveSpeed)
+}
+
+// sendTroop starts a new deployment of a summoned troop and sends it down its
+// lane. It starts attacking once it arrives.
+func (ge *GameEngine) sendTroop(playerID string, troop *Troop) {
+	ge.deploy(playerID, troop.Name)
+	troop.Travel = travelTicks(troop)
+}
+
+// advanceTroops walks every troop in transit one tick down its lane. The ones
+// that arrive are announced and attack right away, then every hit speed.
+func (ge *GameEngine) advanceTroops() {
+	for _, player := range []*Player{&ge.gameState.Player1, &ge.gameState.Player2} {
+		for i := range player.Troops {
+			troop := &player.Troops[i]
+			if !troop.Deployed || troop.HP <= 0 || troop.Travel <= 0 {
+				continue
+			}
+
+			troop.Travel--
+			if troop.Travel == 0 {
+				ge.troopArrived(player, troop)
+			}
+		}
+	}
+}
+
+// troopArrived announces a troop reaching the fight and starts its attacks
+func (ge *GameEngine) troopArrived(player *Player, troop *Troop) {
+	data := map[string]interface{}{
+		"owner": player.Username,
+		"lane":  string(troop.Lane),
+	}
+	ge.logEvent(EventTroopArrived, player.ID, data)
+
+	ge.broadcastAction(CombatAction{
+		Type:      EventTroopArrived,
+		PlayerID:  player.ID,
+		TroopName: troop.Name,
+		Timestamp: ge.clock.Now(),
+		Data:      data,
+	})
+
+	deployment := ge.deployments[troopKey{playerID: player.ID, troop: troop.Name}]
+	ge.scheduleTroopAttack(player.ID, troop.Name, deployment, 0)
+}
+
+// deploy starts a new deployment of the troop. Attack chains of an earlier
+// deployment stop once a newer one exists.
+func (ge *GameEngine) deploy(playerID string, troopName TroopType) {
+	ge.deployments[troopKey{playerID: playerID, troop: troopName}]++
+}
+
+// fieldTroop returns the troop while the given deployment of it is still on
+// the field, nil once it died or was summoned again
+func (ge *GameEngine) fieldTroop(playerID string, troopName TroopType, deployment int) *Troop {
+	if ge.deployments[troopKey{playerID: playerID, troop: troopName}] != deployment {
+		return nil
+	}
+
+	player := ge.getPlayer(playerID)
+	if player == nil {
+		return nil
+	}
+	for i := range player.Troops {
+		troop := &player.Troops[i]
+		if troop.Name == troopName && engaged(troop) {
+			return troop
+		}
+	}
+	return nil
+}
+
+// scheduleTroopAttack queues the next attack of a troop on the field, which
+// queues the one after it until the deployment leaves the field
+func (ge *GameEngine) scheduleTroopAttack(playerID string, troopName TroopType, deployment, delayTicks int) {
+	ge.schedule(delayTicks, phaseAttack, func() {
+		troop := ge.fieldTroop(playerID, troopName, deployment)
+		if troop == nil {
+			return
+		}
+
+		if attackAction := ge.executeAutoAttack(playerID, troopName); attackAction != nil {
+			ge.broadcastAction(*attackAction)
+		}
+
+		ge.scheduleTroopAttack(playerID, troopName, deployment, secondsToTicks(troop.HitSpeed))
+	})
+}
@@ -0,0 +1,53 @@
+// Package game implements the hand and card cycle
+package game
+
+// dealHand deals the opening hand in deck order: the first handSize cards go
+// to the hand, the next one to the preview slot and the rest to the queue
+func dealHand(player *Player, handSize int) {
+	cards := make([]TroopType, len(player.Troops))
+	for i := range player.Troops {
+		cards[i] = player.Troops[i].Name
+	}
+
+	if handSize > len(cards) {
+		handSize = len(cards)
+	}
+
+	player.Hand = append([]TroopType(nil), cards[:handSize]...)
+	player.NextCard = ""
+	player.Queue = nil
+	if rest := cards[handSize:]; len(rest) > 0 {
+		player.NextCard = rest[0]
+		player.Queue = append([]TroopType(nil), rest[1:]...)
+	}
+}
+
+// inHand reports whether the card can be played now
+func inHand(player *Player, card TroopType) bool {
+	for _, handCard := range player.Hand {
+		if handCard == card {
+			return true
+		}
+	}
+	return false
+}
+
+// cycleCard sends a played card to the back of the queue and draws the next
+// card into its slot. A deck no bigger than the hand has nothing to draw.
+func cycleCard(player *Player, card TroopType) {
+	if player.NextCard == "" {
+		return
+	}
+
+	for i, handCard := range player.Hand {
+		if handCard != card {
+			continue
+		}
+
+		player.Hand[i] = player.NextCard
+		player.Queue = append(player.Queue, card)
+		player.NextCard = player.Queue[0]
+		player.Queue = player.Queue[1:]
+		return
+	}
+}
@@ -0,0 +1,66 @@
+package game
+
+import (
+	"sync"
+	"testing"
+)
+
+// TestConcurrentHandPlay has two bots play their hands, troops and spells
+// into one Enhanced match while the loop ticks. Run it with -race.
+func TestConcurrentHandPlay(t *testing.T) {
+	ge, clock, _ := startMatch(t, ModeEnhanced, matchOptions{seed: 7, clock: true})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for ge.IsRunning() {
+			clock.Advance(TickDuration)
+		}
+	}()
+
+	state := ge.GetGameState()
+	var bots sync.WaitGroup
+	for _, bot := range []Player{state.Player1, state.Player2} {
+		bots.Add(1)
+		go func(playerID string) {
+			defer bots.Done()
+			lanes := []Lane{LaneLeft, LaneRight}
+			for i := 0; i < 500 && ge.IsRunning(); i++ {
+				// Most commands are rejected (no mana, not deployed), the loop
+				// only has to take them one at a time
+				me := ge.GetGameState().Player1
+				if me.ID != playerID {
+					me = ge.GetGameState().Player2
+				}
+				for _, card := range me.Hand {
+					ge.SummonTroop(playerID, card, lanes[i%2])
+				}
+				for _, troop := range me.Troops {
+					ge.ExecuteAttack(playerID, troop.Name, "tower", "Guard Tower 1")
+				}
+				for _, spell := range me.Spells {
+					ge.CastSpell(playerID, spell.Name, "Guard Tower 2")
+				}
+			}
+		}(bot.ID)
+	}
+	bots.Wait()
+
+	ge.StopGame()
+	<-done
+
+	final := ge.GetGameState()
+	if final.Status != StatusFinished {
+		t.Fatalf("status = %s, want %s", final.Status, StatusFinished)
+	}
+	for _, player := range []Player{final.Player1, final.Player2} {
+		if player.Mana < 0 || player.Mana > final.Rules.MaxMana {
+			t.Errorf("%s has %d mana, outside 0..%d", player.Username, player.Mana, final.Rules.MaxMana)
+		}
+		for _, tower := range player.Towers {
+			if tower.HP < 0 || tower.HP > tower.MaxHP {
+				t.Errorf("%s %s has %d HP, outside 0..%d", player.Username, tower.Name, tower.HP, tower.MaxHP)
+			}
+		}
+	}
+}
@@ -0,0 +1,41 @@
+// Package game implements King Tower activation
+package game
+
+// Why a dormant King Tower woke up, reported in KING_ACTIVATED
+const (
+	KingActivatedGuardDestroyed = "guard_destroyed"
+	KingActivatedHit            = "king_hit"
+)
+
+// towerHit wakes the owner's King Tower when it is the tower that was hit
+func (ge *GameEngine) towerHit(owner *Player, tower *Tower) {
+	if tower.Name == KingTower {
+		ge.activateKingTower(owner, KingActivatedHit)
+	}
+}
+
+// activateKingTower wakes the owner's dormant King Tower so it starts
+// defending, and announces it to both players
+func (ge *GameEngine) activateKingTower(owner *Player, reason string) {
+	king := findTower(owner, KingTower)
+	if king == nil || king.IsActive || king.HP <= 0 {
+		return
+	}
+	king.IsActive = true
+
+	data := map[string]interface{}{
+		"owner":  owner.Username,
+		"reason": reason,
+	}
+	ge.logger.Info("%s's King Tower activated (%s)", owner.Username, reason)
+	ge.logEvent(EventKingActivated, owner.ID, data)
+
+	ge.broadcastAction(CombatAction{
+		Type:       EventKingActivated,
+		PlayerID:   owner.ID,
+		TargetType: "tower",
+		TargetName: string(KingTower),
+		Timestamp:  ge.clock.Now(),
+		Data:       data,
+	})
+}
@@ -0,0 +1,128 @@
+// Package game implements the two-lane battlefield
+package game
+
+import "fmt"
+
+// Lane is one of the two paths troops march down towards the enemy towers
+type Lane string
+
+// Lane constants, each lane is defended by one Guard Tower
+const (
+	LaneLeft  Lane = "left"  // Defended by Guard Tower 1
+	LaneRight Lane = "right" // Defended by Guard Tower 2
+)
+
+// Lanes lists the battlefield lanes in display order
+var Lanes = []Lane{LaneLeft, LaneRight}
+
+// ParseLane validates a lane name received from a client
+func ParseLane(name string) (Lane, error) {
+	for _, lane := range Lanes {
+		if string(lane) == name {
+			return lane, nil
+		}
+	}
+	return "", fmt.Errorf("invalid lane %q: choose %q or %q", name, LaneLeft, LaneRight)
+}
+
+// GuardTower returns the Guard Tower defending the lane
+func (l Lane) GuardTower() TowerType {
+	switch l {
+	case LaneLeft:
+		return GuardTower1
+	case LaneRight:
+		return GuardTower2
+	}
+	return ""
+}
+
+// LaneOf returns the lane a Guard Tower defends, empty for the King Tower
+func LaneOf(tower TowerType) Lane {
+	for _, lane := range Lanes {
+		if lane.GuardTower() == tower {
+			return lane
+		}
+	}
+	return ""
+}
+
+// findTower returns the player's tower with the given name, or nil
+func findTower(player *Player, name TowerType) *Tower {
+	for i := range player.Towers {
+		if player.Towers[i].Name == name {
+			return &player.Towers[i]
+		}
+	}
+	return nil
+}
+
+// laneOpen reports whether the lane's Guard Tower has fallen, which
+// exposes the King Tower to troops in that lane
+func laneOpen(player *Player, lane Lane) bool {
+	guard := findTower(player, lane.GuardTower())
+	return guard == nil || guard.HP <= 0
+}
+
+// kingExposed reports whether either lane is open, which exposes the King
+// Tower to spells
+func kingExposed(player *Player) bool {
+	for _, lane := range Lanes {
+		if laneOpen(player, lane) {
+			return true
+		}
+	}
+	return false
+}
+
+// laneTowerTarget returns the tower a troop in the lane fights: the lane's
+// Guard Tower, then the King Tower once the lane is open. Nil when both are down.
+func laneTowerTarget(player *Player, lane Lane) *Tower {
+	if !laneOpen(player, lane) {
+		return findTower(player, lane.GuardTower())
+	}
+
+	if king := findTower(player, KingTower); king != nil && king.HP > 0 {
+		return king
+	}
+	return nil
+}
+
+// laneTroopTarget returns the weakest enemy troop deployed in the lane, or nil
+func laneTroopTarget(player *Player, lane Lane) *Troop {
+	var target *Troop
+	for i := range player.Troops {
+		troop := &player.Troops[i]
+		if !engaged(troop) || troop.IsSupport() || troop.Lane != lane {
+			continue
+		}
+		if target == nil || troop.HP < target.HP {
+			target = troop
+		}
+	}
+	return target
+}
+
+// validateLaneTarget checks that a troop can reach the target from its lane.
+// Guard Towers are only reachable from their own lane and the King Tower only
+// once the attacker's lane is open.
+func validateLaneTarget(opponent *Player, attacker *Troop, targetType, targetName string) error {
+	if targetType != "tower" {
+		return nil
+	}
+
+	tower := TowerType(targetName)
+	if tower == KingTower {
+		if !laneOpen(opponent, attacker.Lane) {
+			return fmt.Errorf("must destroy %s before attacking King Tower from the %s lane",
+				attacker.Lane.GuardTower(), attacker.Lane)
+		}
+		return nil
+	}
+
+	if lane := LaneOf(tower); lane != "" && lane != attacker.Lane {
+		return fmt.Errorf("%s is in the %s lane and cannot reach %s in the %s lane",
+			attacker.Name, attacker.Lane, tower, lane)
+	}
+
+	return nil
+}
@@ -0,0 +1,176 @@
+// Package game implements the simulation loop that owns the game state
+package game
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// Scheduled action phases, resolved in this order within a tick
+const (
+	phaseAttack = iota
+)
+
+// engineCommand is a player command queued for the simulation loop
+type engineCommand struct {
+	apply func() (*CombatAction, error)
+	reply chan commandResult
+}
+
+// commandResult carries the outcome of a command back to the caller
+type commandResult struct {
+	action *CombatAction
+	err    error
+}
+
+// scheduledAction is combat the loop resolves once its tick comes due
+type scheduledAction struct {
+	dueTick int
+	phase   int
+	seq     int
+	run     func()
+}
+
+// runLoop is the single authoritative simulation loop. It owns the game
+// state: player commands are drained from the inbox one at a time, and in
+// Enhanced mode each tick resolves troop arrivals, attacks, tower fire,
+// status effects and mana regeneration in order. In Simple mode ticks only
+// run the turn clock. A snapshot is published after every change.
+func (ge *GameEngine) runLoop() {
+	defer close(ge.loopDone)
+	defer ge.finishRecording()
+
+	// Simple mode has no clock-driven combat, only commands and the turn clock
+	var ticks <-chan time.Time
+	if ge.clockDriven() {
+		ticker := ge.clock.NewTicker(TickDuration)
+		defer ticker.Stop()
+		ticks = ticker.C()
+	}
+
+	for {
+		select {
+		case cmd := <-ge.commands:
+			action, err := cmd.apply()
+			ge.publishSnapshot()
+			cmd.reply <- commandResult{action: action, err: err}
+		case <-ticks:
+			ge.step()
+			ge.publishSnapshot()
+		}
+
+		if !ge.isRunning {
+			return
+		}
+	}
+}
+
+// step advances the simulation by one tick
+func (ge *GameEngine) step() {
+	// A paused match stands still, its clocks included
+	if ge.gameState.Paused {
+		return
+	}
+
+	ge.tick++
+
+	if ge.gameState.GameMode == ModeSimple {
+		ge.tickTurnClock()
+		return
+	}
+
+	ge.advanceTroops()
+
+	ge.resolveScheduled()
+
+	if ge.isRunning {
+		ge.fireTowers()
+	}
+
+	if ge.isRunning && ge.tick%TickRate == 0 {
+		ge.tickEffects(&ge.gameState.Player1, &ge.gameState.Player2)
+	}
+
+	if ge.isRunning && ge.tick%TickRate == 0 {
+		ge.regenerateMana()
+	}
+
+	if ge.isRunning {
+		ge.checkWinConditions()
+	}
+}
+
+// resolveScheduled runs every scheduled action due this tick
+func (ge *GameEngine) resolveScheduled() {
+	ge.resolveDue(&ge.scheduled, ge.tick)
+}
+
+// resolveDue runs the actions in queue due at now, in phase order, then in
+// scheduling order. Actions may queue more.
+func (ge *GameEngine) resolveDue(queue *[]scheduledAction, now int) {
+	var due, pending []scheduledAction
+	for _, sa := range *queue {
+		if sa.dueTick <= now {
+			due = append(due, sa)
+		} else {
+			pending = append(pending, sa)
+		}
+	}
+	*queue = pending
+
+	sort.Slice(due, func(i, j int) bool {
+		if due[i].phase != due[j].phase {
+			return due[i].phase < due[j].phase
+		}
+		return due[i].seq < due[j].seq
+	})
+
+	for _, sa := range due {
+		if !ge.isRunning {
+			return
+		}
+		sa.run()
+	}
+}
+
+// schedule queues fn to run delayTicks from now in the given phase
+func (ge *GameEngine) schedule(delayTicks int, phase int, fn func()) {
+	ge.scheduled = append(ge.scheduled, scheduledAction{
+		dueTick: ge.tick + delayTicks,
+		phase:   phase,
+		seq:     ge.scheduleSeq,
+		run:     fn,
+	})
+	ge.scheduleSeq++
+}
+
+// submit hands a player command to the simulation loop and waits for the
+// result. Before StartGame the command runs directly on the caller.
+func (ge *GameEngine) submit(apply func() (*CombatAction, error)) (*CombatAction, error) {
+	if !ge.loopStarted.Load() {
+		action, err := apply()
+		ge.publishSnapshot()
+		return action, err
+	}
+
+	reply := make(chan commandResult, 1)
+	select {
+	case ge.commands <- engineCommand{apply: apply, reply: reply}:
+	case <-ge.loopDone:
+		return nil, fmt.Errorf("game is not running")
+	}
+
+	result := <-reply
+	return result.action, result.err
+}
+
+// secondsToTicks converts a duration in seconds to whole ticks, at least one
+func secondsToTicks(seconds float64) int {
+	ticks := int(math.Round(seconds * TickRate))
+	if ticks < 1 {
+		ticks = 1
+	}
+	return ticks
+}
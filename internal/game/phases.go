@@ -0,0 +1,76 @@
+// Package game implements the Enhanced mode match phases
+package game
+
+// advancePhase starts double mana once regulation reaches its final seconds
+func (ge *GameEngine) advancePhase() {
+	if ge.gameState.Phase != PhaseRegular || ge.gameState.TimeLeft <= 0 {
+		return
+	}
+	if ge.gameState.TimeLeft > ge.gameState.Rules.DoubleManaSeconds {
+		return
+	}
+
+	ge.enterPhase(PhaseDoubleMana, EventDoubleMana, nil)
+}
+
+// startOvertime gives a match tied on towers lost at timeout a sudden death
+// period. Returns false when overtime is off or already played.
+func (ge *GameEngine) startOvertime() bool {
+	if ge.gameState.Rules.OvertimeSeconds <= 0 || ge.gameState.Phase == PhaseOvertime {
+		return false
+	}
+
+	ge.gameState.TimeLeft = ge.gameState.Rules.OvertimeSeconds
+	ge.enterPhase(PhaseOvertime, EventOvertime, map[string]interface{}{
+		"towers_p1": ge.gameState.TowersKilled.Player1,
+		"towers_p2": ge.gameState.TowersKilled.Player2,
+	})
+	return true
+}
+
+// enterPhase switches the match phase and announces it to both players
+func (ge *GameEngine) enterPhase(phase, eventType string, data map[string]interface{}) {
+	ge.gameState.Phase = phase
+
+	if data == nil {
+		data = make(map[string]interface{})
+	}
+	data["phase"] = phase
+	data["time_left"] = ge.gameState.TimeLeft
+	data["mana_regen"] = ge.gameState.Rules.ManaRegenRate(phase)
+
+	ge.logger.Info("Match phase: %s (%ds left)", phase, ge.gameState.TimeLeft)
+	ge.logEvent(eventType, "", data)
+
+	ge.broadcastAction(CombatAction{
+		Type:      eventType,
+		Timestamp: ge.clock.Now(),
+		Data:      data,
+	})
+}
+
+// checkSuddenDeath ends an overtime match as soon as either side loses a
+// tower. Overtime only starts with towers lost tied, so the first tower
+// destroyed decides it.
+func (ge *GameEngine) checkSuddenDeath() bool {
+	if ge.gameState.Phase != PhaseOvertime {
+		return false
+	}
+
+	lost1 := ge.gameState.TowersKilled.Player1
+	lost2 := ge.gameState.TowersKilled.Player2
+	if lost1 == lost2 {
+		return false
+	}
+
+	if lost1 < lost2 {
+		ge.gameState.Winner = ge.gameState.Player1.ID
+	} else {
+		ge.gameState.Winner = ge.gameState.Player2.ID
+	}
+	ge.logger.Info("Sudden death - %s wins by destroying the first tower in overtime", ge.gameState.Winner)
+
+	ge.awardGameEndEXP()
+	ge.endGame(EndSuddenDeath)
+	return true
+}
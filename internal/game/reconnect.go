@@ -0,0 +1,53 @@
+// Package game implements pausing a match while a player reconnects
+package game
+
+import "fmt"
+
+// Pause holds a Simple mode match while a player reconnects: the turn clock
+// and chess clock stop and moves are refused. Enhanced matches keep running.
+func (ge *GameEngine) Pause() {
+	ge.submit(func() (*CombatAction, error) {
+		if ge.isRunning && ge.gameState.GameMode == ModeSimple {
+			ge.gameState.Paused = true
+			ge.logger.Info("Match %s paused", ge.gameState.ID)
+		}
+		return nil, nil
+	})
+}
+
+// Resume carries on a paused match
+func (ge *GameEngine) Resume() {
+	ge.submit(func() (*CombatAction, error) {
+		if ge.gameState.Paused {
+			ge.gameState.Paused = false
+			ge.logger.Info("Match %s resumed", ge.gameState.ID)
+		}
+		return nil, nil
+	})
+}
+
+// checkPaused refuses moves while the match is paused
+func (ge *GameEngine) checkPaused() error {
+	if ge.gameState.Paused {
+		return fmt.Errorf("game is paused until your opponent reconnects")
+	}
+	return nil
+}
+
+// ForfeitDisconnect forfeits the match for a player who lost their
+// connection and did not come back in time
+func (ge *GameEngine) ForfeitDisconnect(playerID string) error {
+	_, err := ge.submit(func() (*CombatAction, error) {
+		if !ge.isRunning {
+			return nil, fmt.Errorf("game is not running")
+		}
+		if ge.getPlayer(playerID) == nil {
+			return nil, fmt.Errorf("player not found")
+		}
+
+		ge.forfeit(playerID, EndDisconnect)
+		ge.recordCommand(ReplayCommand{Type: ReplayCommandDisconnect, PlayerID: playerID})
+		return nil, nil
+	})
+	return err
+}
@@ -0,0 +1,398 @@
+// Package game handles match replay recording and loading
+package game
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Replay entry kinds, one JSON object per line in the replay file
+const (
+	ReplayHeaderEntry  = "header"
+	ReplayCommandEntry = "command"
+	ReplayEventEntry   = "event"
+	ReplayFinalEntry   = "final"
+)
+
+// Replay command types not covered by the ActionType constants
+const (
+	ReplayCommandStop         = "stop"
+	ReplayCommandMoveDeadline = "move_deadline" // The player to move missed a correspondence deadline
+	ReplayCommandDisconnect   = "disconnect"    // A player did not reconnect in time
+)
+
+// ReplayEntry is a single line of a replay file
+type ReplayEntry struct {
+	Kind    string         `json:"kind"`
+	Seq     int            `json:"seq"`  // Position in the file, for stable ordering
+	Tick    int            `json:"tick"` // Simulation tick the entry happened on
+	Header  *ReplayHeader  `json:"header,omitempty"`
+	Command *ReplayCommand `json:"command,omitempty"`
+	Event   *CombatAction  `json:"event,omitempty"`
+	State   *GameState     `json:"state,omitempty"`
+}
+
+// ReplayHeader holds everything needed to re-run a match from the start
+type ReplayHeader struct {
+	GameID       string     `json:"game_id"`
+	GameMode     string     `json:"game_mode"`
+	Seed         int64      `json:"seed"`
+	SpecsVersion string     `json:"specs_version"`
+	Specs        *GameSpecs `json:"specs"`
+	InitialState *GameState `json:"initial_state"`
+	RecordedAt   time.Time  `json:"recorded_at"`
+}
+
+// ReplayCommand is an accepted player command
+type ReplayCommand struct {
+	Type       string    `json:"type"` // "summon", "attack", "cast_spell", "end_turn", "surrender", "stop", "move_deadline", "disconnect"
+	PlayerID   string    `json:"player_id"`
+	TroopName  TroopType `json:"troop_name,omitempty"`
+	SpellName  SpellType `json:"spell_name,omitempty"`
+	Lane       Lane      `json:"lane,omitempty"`
+	TargetType string    `json:"target_type,omitempty"`
+	TargetName string    `json:"target_name,omitempty"`
+}
+
+// Replay is a fully loaded replay file
+type Replay struct {
+	Header     ReplayHeader
+	Commands   []ReplayEntry
+	Events     []ReplayEntry
+	FinalState *GameState
+	FinalTick  int
+	LastSeq    int // Seq of the last entry, a resumed recording carries on after it
+}
+
+// ReplayRecorder appends replay entries for one match to a JSONL file
+type ReplayRecorder struct {
+	closer  io.Closer // nil when recording to a plain writer
+	writer  *bufio.Writer
+	encoder *json.Encoder
+	seq     int
+	mu      sync.Mutex
+}
+
+// NewReplayRecorder creates data/replays/<gameID>.jsonl for recording
+func NewReplayRecorder(dir, gameID string) (*ReplayRecorder, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create replay directory: %w", err)
+	}
+
+	// Never overwrite another match's replay, for a correspondence game it is
+	// the only saved copy
+	file, err := os.OpenFile(ReplayPath(dir, gameID), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create replay file: %w", err)
+	}
+
+	recorder := NewReplayWriter(file)
+	recorder.closer = file
+	return recorder, nil
+}
+
+// ContinueReplayRecorder appends to the existing replay of gameID, numbering
+// entries on from lastSeq. Used for a resumed correspondence match.
+func ContinueReplayRecorder(dir, gameID string, lastSeq int) (*ReplayRecorder, error) {
+	file, err := os.OpenFile(ReplayPath(dir, gameID), os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open replay file: %w", err)
+	}
+
+	recorder := NewReplayWriter(file)
+	recorder.closer = file
+	recorder.seq = lastSeq + 1
+	return recorder, nil
+}
+
+// ReplayPath returns the replay file of gameID in dir
+func ReplayPath(dir, gameID string) string {
+	return filepath.Join(dir, gameID+".jsonl")
+}
+
+// NewReplayWriter records replay entries to w, e.g. an in-memory buffer
+func NewReplayWriter(w io.Writer) *ReplayRecorder {
+	writer := bufio.NewWriter(w)
+	return &ReplayRecorder{
+		writer:  writer,
+		encoder: json.NewEncoder(writer),
+	}
+}
+
+// Record writes one entry and flushes it so a crash leaves a usable file
+func (rr *ReplayRecorder) Record(entry ReplayEntry) error {
+	rr.mu.Lock()
+	defer rr.mu.Unlock()
+
+	entry.Seq = rr.seq
+	rr.seq++
+
+	if err := rr.encoder.Encode(entry); err != nil {
+		return fmt.Errorf("failed to write replay entry: %w", err)
+	}
+	return rr.writer.Flush()
+}
+
+// Close flushes and closes the replay file
+func (rr *ReplayRecorder) Close() error {
+	rr.mu.Lock()
+	defer rr.mu.Unlock()
+
+	err := rr.writer.Flush()
+	if rr.closer != nil {
+		if closeErr := rr.closer.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	return err
+}
+
+// LoadReplay reads a replay file recorded by ReplayRecorder
+func LoadReplay(path string) (*Replay, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open replay file: %w", err)
+	}
+	defer file.Close()
+
+	return ReadReplay(file)
+}
+
+// ReadReplay parses replay entries from r
+func ReadReplay(r io.Reader) (*Replay, error) {
+	replay := &Replay{}
+	hasHeader := false
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024) // Headers embed full specs and state
+	for scanner.Scan() {
+		var entry ReplayEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("failed to parse replay entry: %w", err)
+		}
+		if entry.Seq > replay.LastSeq {
+			replay.LastSeq = entry.Seq
+		}
+
+		switch entry.Kind {
+		case ReplayHeaderEntry:
+			if entry.Header == nil {
+				return nil, fmt.Errorf("replay header entry is empty")
+			}
+			replay.Header = *entry.Header
+			hasHeader = true
+		case ReplayCommandEntry:
+			replay.Commands = append(replay.Commands, entry)
+		case ReplayEventEntry:
+			replay.Events = append(replay.Events, entry)
+		case ReplayFinalEntry:
+			replay.FinalState = entry.State
+			replay.FinalTick = entry.Tick
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read replay: %w", err)
+	}
+
+	if !hasHeader {
+		return nil, fmt.Errorf("replay file has no header")
+	}
+
+	return replay, nil
+}
+
+// SpecsVersion returns a short content hash identifying a set of game specs
+func SpecsVersion(specs *GameSpecs) string {
+	data, err := json.Marshal(struct {
+		TroopSpecs map[TroopType]TroopSpec `json:"troops"`
+		TowerSpecs map[TowerType]TowerSpec `json:"towers"`
+		SpellSpecs map[SpellType]SpellSpec `json:"spells,omitempty"`
+	}{specs.TroopSpecs, specs.TowerSpecs, specs.SpellSpecs})
+	if err != nil {
+		return "unknown"
+	}
+
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:6])
+}
+
+// SetRecorder attaches a replay recorder. Must be called before StartGame.
+func (ge *GameEngine) SetRecorder(recorder *ReplayRecorder) {
+	ge.recorder = recorder
+}
+
+// recordHeader writes the initial state, seed and specs version
+func (ge *GameEngine) recordHeader() {
+	ge.record(ReplayEntry{
+		Kind: ReplayHeaderEntry,
+		Header: &ReplayHeader{
+			GameID:       ge.gameState.ID,
+			GameMode:     ge.gameState.GameMode,
+			Seed:         ge.gameState.Seed,
+			SpecsVersion: ge.gameSpecs.Version,
+			Specs:        ge.gameSpecs,
+			InitialState: ge.gameState.Clone(),
+			RecordedAt:   ge.clock.Now(),
+		},
+	})
+}
+
+// recordCommand writes an accepted player command
+func (ge *GameEngine) recordCommand(command ReplayCommand) {
+	ge.record(ReplayEntry{Kind: ReplayCommandEntry, Command: &command})
+}
+
+// recordEvent writes a combat action emitted to the event channel
+func (ge *GameEngine) recordEvent(action CombatAction) {
+	ge.record(ReplayEntry{Kind: ReplayEventEntry, Event: &action})
+}
+
+// finishRecording writes the final state and closes the replay file
+func (ge *GameEngine) finishRecording() {
+	if ge.recorder == nil {
+		return
+	}
+
+	ge.record(ReplayEntry{Kind: ReplayFinalEntry, State: ge.gameState.Clone()})
+	if err := ge.recorder.Close(); err != nil {
+		ge.logger.Error("Failed to close replay for %s: %v", ge.gameState.ID, err)
+	}
+	ge.recorder = nil
+}
+
+func (ge *GameEngine) record(entry ReplayEntry) {
+	if ge.recorder == nil {
+		return
+	}
+
+	entry.Tick = ge.tick
+	if err := ge.recorder.Record(entry); err != nil {
+		// A broken replay must never break the match itself
+		ge.logger.Error("Replay recording stopped for %s: %v", ge.gameState.ID, err)
+		ge.recorder.Close()
+		ge.recorder = nil
+	}
+}
+
+// Duration returns the match time of the last recorded entry
+func (r *Replay) Duration() time.Duration {
+	ticks := r.FinalTick
+	for _, entry := range r.Events {
+		if entry.Tick > ticks {
+			ticks = entry.Tick
+		}
+	}
+	return time.Duration(ticks) * TickDuration
+}
+
+// RunReplay re-simulates a recorded match from its initial state, seed and
+// accepted commands on a fake clock, so a full match runs in milliseconds.
+// The re-run is recorded to recorder when it is not nil. It returns the
+// final game state; a command rejected on the re-run means the simulation
+// diverged from the recording.
+func RunReplay(replay *Replay, recorder *ReplayRecorder) (*GameState, error) {
+	header := replay.Header
+	if header.InitialState == nil || header.Specs == nil {
+		return nil, fmt.Errorf("replay header is missing initial state or specs")
+	}
+	if header.InitialState.Rules.Mode == "" {
+		return nil, fmt.Errorf("replay was recorded before rulesets and cannot be re-run")
+	}
+
+	player1 := header.InitialState.Player1.clone()
+	player2 := header.InitialState.Player2.clone()
+
+	// No data manager: a replay must never touch persistent player data
+	ge := NewGameEngine(&player1, &player2, header.InitialState.Rules, header.Specs, nil, header.Seed)
+	ge.gameState.ID = header.InitialState.ID
+
+	clock := NewFakeClock(header.InitialState.StartTime)
+	ge.SetClock(clock)
+	if recorder != nil {
+		ge.SetRecorder(recorder)
+	}
+
+	if err := ge.StartGame(); err != nil {
+		return nil, fmt.Errorf("failed to start replay: %w", err)
+	}
+	if ge.clockDriven() {
+		clock.BlockUntil(1) // Wait for the loop to arm its ticker
+	}
+
+	tick := 0
+	for _, entry := range replay.Commands {
+		for tick < entry.Tick {
+			clock.Advance(TickDuration)
+			tick++
+		}
+
+		if err := ge.applyReplayCommand(*entry.Command); err != nil {
+			ge.StopGame()
+			return nil, fmt.Errorf("command %d (%s at tick %d) rejected on re-run: %w",
+				entry.Seq, entry.Command.Type, entry.Tick, err)
+		}
+	}
+
+	// Run out the clock until the engine stops by itself
+	limit := replay.FinalTick
+	if limit < tick {
+		limit = tick
+	}
+	if ge.clockDriven() {
+		for tick <= limit+TickRate {
+			select {
+			case <-ge.loopDone:
+				return ge.GetGameState(), nil
+			default:
+			}
+			clock.Advance(TickDuration)
+			tick++
+		}
+	}
+
+	select {
+	case <-ge.loopDone:
+		return ge.GetGameState(), nil
+	default:
+	}
+
+	// The recording ended with the match still running
+	finalState := ge.GetGameState()
+	ge.StopGame()
+	<-ge.loopDone // The recorder is only done once the loop has exited
+	return finalState, nil
+}
+
+// applyReplayCommand re-issues a recorded command through the public API
+func (ge *GameEngine) applyReplayCommand(command ReplayCommand) error {
+	var err error
+	switch command.Type {
+	case ActionSummon:
+		_, err = ge.SummonTroop(command.PlayerID, command.TroopName, command.Lane)
+	case ActionAttack:
+		_, err = ge.ExecuteAttack(command.PlayerID, command.TroopName, command.TargetType, command.TargetName)
+	case ActionCastSpell:
+		_, err = ge.CastSpell(command.PlayerID, command.SpellName, command.TargetName)
+	case ActionEndTurn:
+		err = ge.EndTurn(command.PlayerID)
+	case ActionSurrender:
+		err = ge.Surrender(command.PlayerID)
+	case ReplayCommandMoveDeadline:
+		err = ge.ForfeitMoveDeadline(command.PlayerID)
+	case ReplayCommandDisconnect:
+		err = ge.ForfeitDisconnect(command.PlayerID)
+	case ReplayCommandStop:
+		ge.StopGame()
+	default:
+		err = fmt.Errorf("unknown command type %q", command.Type)
+	}
+	return err
+}
@@ -0,0 +1,128 @@
+package game
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+// TestReplayRoundTrip records a match, re-runs it with RunReplay and checks
+// the re-run ends in the same state
+func TestReplayRoundTrip(t *testing.T) {
+	tests := []struct {
+		ruleset string
+		play    func(t *testing.T, ge *GameEngine, clock *FakeClock)
+	}{
+		{ModeEnhanced, playRecordedEnhancedMatch},
+		{ModeSimple, playRecordedSimpleMatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ruleset, func(t *testing.T) {
+			ge, clock, recording := startMatch(t, tt.ruleset, matchOptions{seed: 21, clock: true, record: true})
+
+			tt.play(t, ge, clock)
+			if ge.IsRunning() {
+				ge.StopGame()
+			}
+			<-ge.loopDone // The final state is recorded on the way out
+			played := ge.GetGameState()
+
+			replay, err := ReadReplay(recording)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(replay.Commands) == 0 {
+				t.Fatal("the recording has no commands to re-run")
+			}
+
+			rerun, err := RunReplay(replay, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			want, _ := json.Marshal(played)
+			got, _ := json.Marshal(rerun)
+			if string(want) != string(got) {
+				t.Fatalf("re-run ended in a different state:\nplayed: %s\nre-run: %s", want, got)
+			}
+			recorded, _ := json.Marshal(replay.FinalState)
+			if string(recorded) != string(want) {
+				t.Fatalf("recorded final state differs from the match:\nrecorded: %s\nplayed:   %s", recorded, want)
+			}
+		})
+	}
+}
+
+// playRecordedEnhancedMatch has both players deploy and player 2 cast a
+// Fireball, then runs the match out
+func playRecordedEnhancedMatch(t *testing.T, ge *GameEngine, clock *FakeClock) {
+	t.Helper()
+
+	state := ge.GetGameState()
+	if _, err := ge.SummonTroop(state.Player1.ID, cheapestCard(t, state.Player1), LaneLeft); err != nil {
+		t.Fatal(err)
+	}
+
+	// Player 2 saves up for the Fireball, then for a troop
+	waitForMana(ge, clock, state.Player2.ID, ge.gameSpecs.SpellSpecs[Fireball].MANA)
+	if _, err := ge.CastSpell(state.Player2.ID, Fireball, string(GuardTower2)); err != nil {
+		t.Fatal(err)
+	}
+	card := cheapestCard(t, playerState(ge, state.Player2.ID))
+	waitForMana(ge, clock, state.Player2.ID, ge.gameSpecs.TroopSpecs[card].MANA)
+	if _, err := ge.SummonTroop(state.Player2.ID, card, LaneRight); err != nil {
+		t.Fatal(err)
+	}
+
+	for ge.IsRunning() {
+		clock.Advance(time.Second)
+	}
+}
+
+// waitForMana advances the clock until the player has the mana
+func waitForMana(ge *GameEngine, clock *FakeClock, playerID string, mana int) {
+	for playerState(ge, playerID).Mana < mana {
+		clock.Advance(TickDuration)
+	}
+}
+
+// playRecordedSimpleMatch plays a few turns of a Simple match, each player
+// deploying, attacking once and ending the turn
+func playRecordedSimpleMatch(t *testing.T, ge *GameEngine, clock *FakeClock) {
+	t.Helper()
+
+	for turn := 0; turn < 6 && ge.IsRunning(); turn++ {
+		me := playerState(ge, ge.GetGameState().CurrentTurn)
+		card := cheapestCard(t, me)
+		if _, err := ge.SummonTroop(me.ID, card, LaneLeft); err != nil {
+			t.Fatal(err)
+		}
+		// Rejected attacks (e.g. a support card) are not recorded
+		ge.ExecuteAttack(me.ID, card, "tower", string(GuardTower1))
+		clock.Advance(time.Second)
+		if err := ge.EndTurn(me.ID); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// TestReplayRecorderKeepsExistingReplay checks a game ID clash fails instead
+// of overwriting the other match's replay
+func TestReplayRecorderKeepsExistingReplay(t *testing.T) {
+	dir := t.TempDir()
+	gameID := generateGameID()
+	if other := generateGameID(); other == gameID {
+		t.Fatalf("two games got the same ID %s", gameID)
+	}
+
+	recorder, err := NewReplayRecorder(dir, gameID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer recorder.Close()
+
+	if _, err := NewReplayRecorder(dir, gameID); err == nil {
+		t.Fatalf("second recorder for %s opened the first one's replay", gameID)
+	}
+}
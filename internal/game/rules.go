@@ -0,0 +1,165 @@
+// Package game implements the match rulesets loaded from rules.json
+package game
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// rulesConfig is the layout of rules.json
+type rulesConfig struct {
+	DeckSize int                `json:"deck_size"` // Troop cards in a deck, the same in every ruleset since saved decks are shared
+	Rulesets map[string]Ruleset `json:"rulesets"`
+}
+
+// ValidateRules checks the deck size and every ruleset. Each game mode needs
+// a ruleset of the same name, it is the one the mode plays by default.
+func ValidateRules(deckSize int, rulesets map[string]Ruleset, troopTypes int) error {
+	if deckSize < 1 || deckSize > troopTypes {
+		return fmt.Errorf("deck_size must be 1-%d (the number of troop types), got %d", troopTypes, deckSize)
+	}
+
+	for _, mode := range []string{ModeSimple, ModeEnhanced} {
+		ruleset, ok := rulesets[mode]
+		if !ok {
+			return fmt.Errorf("missing the default %s ruleset", mode)
+		}
+		if ruleset.Mode != mode {
+			return fmt.Errorf("ruleset %s must have mode %q", mode, mode)
+		}
+	}
+
+	for _, name := range sortedRulesetNames(rulesets) {
+		if err := rulesets[name].Validate(deckSize); err != nil {
+			return fmt.Errorf("ruleset %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// Validate checks the ruleset is playable with decks of deckSize cards
+func (r Ruleset) Validate(deckSize int) error {
+	if r.Mode != ModeSimple && r.Mode != ModeEnhanced {
+		return fmt.Errorf("mode must be %q or %q", ModeSimple, ModeEnhanced)
+	}
+	if r.HandSize < 1 || r.HandSize > deckSize {
+		return fmt.Errorf("hand_size must be 1-%d (the deck size), got %d", deckSize, r.HandSize)
+	}
+	if r.MaxMana < 1 || r.StartingMana < 0 || r.StartingMana > r.MaxMana {
+		return fmt.Errorf("need 0 <= starting_mana <= max_mana and max_mana >= 1")
+	}
+	if r.CritMultiplier < 1 {
+		return fmt.Errorf("crit_multiplier must be at least 1")
+	}
+	if r.WinEXP < 0 || r.LoseEXP < 0 || r.DrawEXP < 0 {
+		return fmt.Errorf("EXP rewards cannot be negative")
+	}
+
+	if r.Mode == ModeSimple {
+		if r.ActionPoints < 0 {
+			return fmt.Errorf("action_points cannot be negative")
+		}
+		// Action points replace the cards per turn limit
+		if r.ActionPoints == 0 && r.CardsPerTurn < 1 {
+			return fmt.Errorf("cards_per_turn must be at least 1")
+		}
+		if r.TurnSeconds < 0 {
+			return fmt.Errorf("turn_seconds cannot be negative")
+		}
+		if r.TimeoutsToForfeit < 0 {
+			return fmt.Errorf("timeouts_to_forfeit cannot be negative")
+		}
+		if r.TimeoutsToForfeit > 0 && r.TurnSeconds == 0 {
+			return fmt.Errorf("timeouts_to_forfeit needs a turn clock, set turn_seconds")
+		}
+		if r.BankSeconds < 0 {
+			return fmt.Errorf("bank_seconds cannot be negative")
+		}
+		if r.IncrementSeconds < 0 {
+			return fmt.Errorf("increment_seconds cannot be negative")
+		}
+		if r.IncrementSeconds > 0 && r.BankSeconds == 0 {
+			return fmt.Errorf("increment_seconds needs a time bank, set bank_seconds")
+		}
+		if r.MoveDeadlineHours < 0 {
+			return fmt.Errorf("move_deadline_hours cannot be negative")
+		}
+		// Correspondence turns last hours and are resumed without re-running a clock
+		if r.MoveDeadlineHours > 0 && (r.TurnSeconds > 0 || r.BankSeconds > 0) {
+			return fmt.Errorf("move_deadline_hours cannot be combined with turn_seconds or bank_seconds")
+		}
+		return nil
+	}
+
+	if r.DurationSeconds < 1 {
+		return fmt.Errorf("duration_seconds must be at least 1")
+	}
+	if r.ManaRegenPerSecond < 0 {
+		return fmt.Errorf("mana_regen_per_second cannot be negative")
+	}
+	if r.DoubleManaSeconds < 0 || r.DoubleManaSeconds > r.DurationSeconds {
+		return fmt.Errorf("double_mana_seconds must be 0-%d", r.DurationSeconds)
+	}
+	if (r.DoubleManaSeconds > 0 || r.OvertimeSeconds > 0) && r.DoubleManaMultiplier < 1 {
+		return fmt.Errorf("double_mana_multiplier must be at least 1")
+	}
+	if r.OvertimeSeconds < 0 {
+		return fmt.Errorf("overtime_seconds cannot be negative")
+	}
+	// Losing fewer towers always wins, the HP tiebreakers only split a tie on it
+	if len(r.Tiebreakers) == 0 || r.Tiebreakers[0] != TiebreakTowersLost {
+		return fmt.Errorf("tiebreakers must start with %q", TiebreakTowersLost)
+	}
+	for _, tiebreaker := range r.Tiebreakers {
+		if !tiebreakers[tiebreaker] {
+			return fmt.Errorf("unknown tiebreaker %q", tiebreaker)
+		}
+	}
+	return nil
+}
+
+// Correspondence reports whether matches are played by correspondence,
+// saved between turns with a move deadline (Simple mode)
+func (r Ruleset) Correspondence() bool {
+	return r.Mode == ModeSimple && r.MoveDeadlineHours > 0
+}
+
+// ClockDriven reports whether matches run on the simulation loop's ticks:
+// always in Enhanced mode, and in Simple mode when turns are timed
+func (r Ruleset) ClockDriven() bool {
+	return r.Mode == ModeEnhanced || r.TurnSeconds > 0 || r.BankSeconds > 0
+}
+
+// MoveDeadline returns how long the player to move has in a correspondence match
+func (r Ruleset) MoveDeadline() time.Duration {
+	return time.Duration(r.MoveDeadlineHours) * time.Hour
+}
+
+// ManaRegenRate returns the mana regenerated per second in a match phase
+func (r Ruleset) ManaRegenRate(phase string) int {
+	if phase == PhaseDoubleMana || phase == PhaseOvertime {
+		return r.ManaRegenPerSecond * r.DoubleManaMultiplier
+	}
+	return r.ManaRegenPerSecond
+}
+
+// GameEndEXP returns the EXP a player earns for the result of a match
+func (r Ruleset) GameEndEXP(won bool, isDraw bool) int {
+	if isDraw {
+		return r.DrawEXP
+	} else if won {
+		return r.WinEXP
+	}
+	return r.LoseEXP
+}
+
+// sortedRulesetNames returns the ruleset names in a stable order
+func sortedRulesetNames(rulesets map[string]Ruleset) []string {
+	names := make([]string, 0, len(rulesets))
+	for name := range rulesets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
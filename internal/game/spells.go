@@ -0,0 +1,275 @@
+// Package game implements the data-driven spell card system
+package game
+
+import (
+	"fmt"
+	"sort"
+)
+
+// What a spell is cast on, shown to the client when picking a target
+const (
+	SpellTargetEnemyTower = "enemy_tower" // The caster picks a standing enemy tower
+	SpellTargetOwnTroops  = "own_troops"  // Every troop the caster has on the field
+)
+
+// Built-in spell effects and the params they read
+const (
+	SpellTowerDamage = "tower_damage" // damage: direct damage to the target tower, ignores DEF
+	SpellFreezeTower = "freeze_tower" // duration: stun effect on the target tower, it holds its fire
+	SpellRage        = "rage"         // percent, duration: rage effect raising the ATK of the caster's deployed troops
+)
+
+// spellContext describes a cast being resolved
+type spellContext struct {
+	playerID string
+	source   TroopType // The spell's name, events report it as the acting unit
+	target   *Tower    // Enemy tower picked by the caster (enemy_tower spells only)
+}
+
+// spellHandler resolves one spell effect. Handlers broadcast their own actions.
+type spellHandler func(ge *GameEngine, spell SpellSpec, ctx spellContext)
+
+// spellEffect is a registered spell effect and the target it needs
+type spellEffect struct {
+	target       string
+	handler      spellHandler
+	enhancedOnly bool // Acts on tower fire, which only happens in Enhanced mode
+}
+
+// spellEffects is the spell registry, keyed by SpellSpec.Effect
+var spellEffects = map[string]spellEffect{
+	SpellTowerDamage: {target: SpellTargetEnemyTower, handler: towerDamageSpell},
+	SpellFreezeTower: {target: SpellTargetEnemyTower, handler: freezeTowerSpell, enhancedOnly: true},
+	SpellRage:        {target: SpellTargetOwnTroops, handler: rageSpell},
+}
+
+// Param returns a numeric spell parameter, or fallback when it is not set
+func (s SpellSpec) Param(name string, fallback float64) float64 {
+	if value, ok := s.Params[name]; ok {
+		return value
+	}
+	return fallback
+}
+
+// Target returns what the spell is cast on, empty for an unknown effect
+func (s SpellSpec) Target() string {
+	return spellEffects[s.Effect].target
+}
+
+// EnhancedOnly reports whether the spell can only be cast in Enhanced mode
+func (s SpellSpec) EnhancedOnly() bool {
+	return spellEffects[s.Effect].enhancedOnly
+}
+
+// ValidateSpells checks every spell names a registered effect
+func ValidateSpells(spellSpecs map[SpellType]SpellSpec) error {
+	for _, name := range sortedSpellTypes(spellSpecs) {
+		if _, ok := spellEffects[spellSpecs[name].Effect]; !ok {
+			return fmt.Errorf("spell %s: unknown effect %q", name, spellSpecs[name].Effect)
+		}
+	}
+	return nil
+}
+
+// sortedSpellTypes returns the spell names in a stable order
+func sortedSpellTypes(spellSpecs map[SpellType]SpellSpec) []SpellType {
+	names := make([]SpellType, 0, len(spellSpecs))
+	for name := range spellSpecs {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return names[i] < names[j] })
+	return names
+}
+
+// CastSpell handles casting a spell card. targetName names the enemy tower
+// for enemy_tower spells and is ignored otherwise.
+func (ge *GameEngine) CastSpell(playerID string, spellName SpellType, targetName string) (*CombatAction, error) {
+	return ge.submit(func() (*CombatAction, error) {
+		action, err := ge.castSpell(playerID, spellName, targetName)
+		if err == nil {
+			ge.recordCommand(ReplayCommand{
+				Type:       ActionCastSpell,
+				PlayerID:   playerID,
+				SpellName:  spellName,
+				TargetType: action.TargetType,
+				TargetName: action.TargetName,
+			})
+			// The cast is only sent to the players by the server, keep it in the replay too
+			ge.recordEvent(*action)
+		}
+		return action, err
+	})
+}
+
+func (ge *GameEngine) castSpell(playerID string, spellName SpellType, targetName string) (*CombatAction, error) {
+	player := ge.getPlayer(playerID)
+	opponent := ge.getOpponent(playerID)
+	if player == nil || opponent == nil {
+		return nil, fmt.Errorf("player not found")
+	}
+
+	if err := ge.checkPaused(); err != nil {
+		return nil, err
+	}
+
+	// A spell takes one of the turn's card plays in Simple mode
+	if ge.gameState.GameMode == ModeSimple {
+		if ge.gameState.CurrentTurn != playerID {
+			return nil, fmt.Errorf("not your turn")
+		}
+		if !ge.actionPointsOn() && player.TroopsDeployedThisTurn >= ge.gameState.Rules.CardsPerTurn {
+			return nil, fmt.Errorf("cannot play more than %d card(s) per turn in simple mode", ge.gameState.Rules.CardsPerTurn)
+		}
+	}
+
+	var spell *Spell
+	for i := range player.Spells {
+		if player.Spells[i].Name == spellName {
+			spell = &player.Spells[i]
+			break
+		}
+	}
+	spec, ok := ge.gameSpecs.SpellSpecs[spellName]
+	if spell == nil || !ok {
+		return nil, fmt.Errorf("spell not available")
+	}
+
+	// Towers never fire in Simple mode, so there is nothing for a tower stun to hold
+	if ge.gameState.GameMode == ModeSimple && spec.EnhancedOnly() {
+		return nil, fmt.Errorf("%s can only be cast in enhanced mode, towers do not fire in simple mode", spellName)
+	}
+
+	// A spell costs its mana in action points (Simple mode)
+	if err := ge.checkActionPoints(player, spell.MANA, string(spellName)); err != nil {
+		return nil, err
+	}
+
+	ctx := spellContext{playerID: playerID, source: TroopType(spellName)}
+	action := CombatAction{
+		Type:      ActionCastSpell,
+		PlayerID:  playerID,
+		TroopName: ctx.source,
+		Timestamp: ge.clock.Now(),
+	}
+
+	switch spec.Target() {
+	case SpellTargetEnemyTower:
+		ctx.target = findTower(opponent, TowerType(targetName))
+		if ctx.target == nil {
+			return nil, fmt.Errorf("target tower not found")
+		}
+		if ctx.target.HP <= 0 {
+			return nil, fmt.Errorf("target tower is already destroyed")
+		}
+		if ctx.target.Name == KingTower && !kingExposed(opponent) {
+			return nil, fmt.Errorf("must destroy a Guard Tower before casting %s on the King Tower", spellName)
+		}
+		action.TargetType = "tower"
+		action.TargetName = targetName
+	case SpellTargetOwnTroops:
+		if !hasFieldTroops(player) {
+			return nil, fmt.Errorf("%s needs at least one troop on the field", spellName)
+		}
+	default:
+		return nil, fmt.Errorf("spell %s has unknown effect %q", spellName, spec.Effect)
+	}
+
+	// Check mana cost first so a rejected cast costs nothing (Enhanced mode only)
+	if ge.gameState.GameMode == ModeEnhanced {
+		if player.Mana < spell.MANA {
+			return nil, fmt.Errorf("insufficient mana: need %d, have %d", spell.MANA, player.Mana)
+		}
+		player.Mana -= spell.MANA
+	}
+
+	if ge.gameState.GameMode == ModeSimple {
+		player.TroopsDeployedThisTurn++
+		ge.spendActionPoints(player, spell.MANA)
+	}
+
+	ge.logEvent("CAST_SPELL", playerID, map[string]interface{}{
+		"spell":     spellName,
+		"effect":    spec.Effect,
+		"target":    action.TargetName,
+		"mana_left": player.Mana,
+	})
+
+	spellEffects[spec.Effect].handler(ge, spec, ctx)
+
+	action.Data = map[string]interface{}{
+		"spell":                     string(spellName),
+		"effect":                    spec.Effect,
+		"mana_left":                 player.Mana,
+		"troops_deployed_this_turn": player.TroopsDeployedThisTurn,
+		"action_points":             player.ActionPoints,
+	}
+
+	ge.updatePlayerInState(player)
+	ge.updatePlayerInState(opponent)
+
+	if ge.isRunning {
+		ge.checkWinConditions()
+	}
+
+	return &action, nil
+}
+
+// hasFieldTroops reports whether the player has a troop deployed in a lane
+func hasFieldTroops(player *Player) bool {
+	for _, troop := range player.Troops {
+		if troop.Deployed && troop.HP > 0 && !troop.IsSupport() {
+			return true
+		}
+	}
+	return false
+}
+
+// towerDamageSpell hits the target tower directly, e.g. Fireball
+func towerDamageSpell(ge *GameEngine, spell SpellSpec, ctx spellContext) {
+	damage := int(spell.Param("damage", 0))
+	if damage <= 0 {
+		return
+	}
+	ge.abilityDamageTower(ctx.playerID, ctx.source, spell.Effect, ctx.target, damage)
+}
+
+// freezeTowerSpell stuns the target tower so it holds its fire, e.g. Freeze
+func freezeTowerSpell(ge *GameEngine, spell SpellSpec, ctx spellContext) {
+	duration := int(spell.Param("duration", 1))
+	opponent := ge.getOpponent(ctx.playerID)
+	if duration <= 0 || opponent == nil {
+		return
+	}
+
+	ge.applyEffect(towerCarrier(opponent, ctx.target), StatusEffect{
+		Type:      EffectStun,
+		Source:    ctx.source,
+		SourceID:  ctx.playerID,
+		Remaining: duration,
+	})
+}
+
+// rageSpell raises the ATK of every troop the caster has on the field, e.g. Rage
+func rageSpell(ge *GameEngine, spell SpellSpec, ctx spellContext) {
+	duration := int(spell.Param("duration", 1))
+	percent := spell.Param("percent", 0)
+	player := ge.getPlayer(ctx.playerID)
+	if duration <= 0 || percent <= 0 || player == nil {
+		return
+	}
+
+	for i := range player.Troops {
+		troop := &player.Troops[i]
+		if !troop.Deployed || troop.HP <= 0 || troop.IsSupport() {
+			continue
+		}
+
+		ge.applyEffect(troopCarrier(player, troop), StatusEffect{
+			Type:      EffectRage,
+			Source:    ctx.source,
+			SourceID:  ctx.playerID,
+			ATKMod:    int(float64(troop.ATK) * percent / 100),
+			Remaining: duration,
+		})
+	}
+}
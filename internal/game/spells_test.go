@@ -0,0 +1,79 @@
+package game
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// playersByTurn returns the player whose turn it is along with the opponent
+func playersByTurn(ge *GameEngine) (Player, Player) {
+	state := ge.GetGameState()
+	if state.CurrentTurn == state.Player2.ID {
+		return state.Player2, state.Player1
+	}
+	return state.Player1, state.Player2
+}
+
+// playerState returns a copy of one player's state
+func playerState(ge *GameEngine, playerID string) Player {
+	state := ge.GetGameState()
+	if state.Player2.ID == playerID {
+		return state.Player2
+	}
+	return state.Player1
+}
+
+func TestFreezeRejectedInSimpleMode(t *testing.T) {
+	for _, ruleset := range []string{ModeSimple, "tactics"} {
+		t.Run(ruleset, func(t *testing.T) {
+			ge, _, _ := startMatch(t, ruleset, matchOptions{seed: 11, clock: true})
+			me, opponent := playersByTurn(ge)
+			defer ge.StopGame()
+
+			_, err := ge.CastSpell(me.ID, Freeze, string(GuardTower1))
+			if err == nil || !strings.Contains(err.Error(), "enhanced mode") {
+				t.Fatalf("casting Freeze in simple mode: err = %v, want an enhanced mode only error", err)
+			}
+
+			after := playerState(ge, me.ID)
+			if after.TroopsDeployedThisTurn != me.TroopsDeployedThisTurn {
+				t.Fatalf("rejected Freeze used a card play: %d, was %d", after.TroopsDeployedThisTurn, me.TroopsDeployedThisTurn)
+			}
+			if after.ActionPoints != me.ActionPoints {
+				t.Fatalf("rejected Freeze spent action points: %d, was %d", after.ActionPoints, me.ActionPoints)
+			}
+			for _, tower := range playerState(ge, opponent.ID).Towers {
+				if len(tower.Effects) > 0 {
+					t.Fatalf("%s picked up %v from a rejected Freeze", tower.Name, tower.Effects)
+				}
+			}
+
+			// The turn's card play is still there for another spell
+			if _, err := ge.CastSpell(me.ID, Fireball, string(GuardTower1)); err != nil {
+				t.Fatalf("Fireball after a rejected Freeze: %v", err)
+			}
+		})
+	}
+}
+
+func TestFreezeStunsTowerInEnhancedMode(t *testing.T) {
+	ge, clock, _ := startMatch(t, ModeEnhanced, matchOptions{seed: 11, clock: true})
+	me, opponent := playersByTurn(ge)
+	defer ge.StopGame()
+	clock.BlockUntil(1) // The loop's ticker
+
+	// Wait for enough mana to cast it
+	for playerState(ge, me.ID).Mana < ge.gameSpecs.SpellSpecs[Freeze].MANA {
+		clock.Advance(time.Second)
+	}
+
+	if _, err := ge.CastSpell(me.ID, Freeze, string(GuardTower1)); err != nil {
+		t.Fatal(err)
+	}
+
+	target := playerState(ge, opponent.ID)
+	if tower := findTower(&target, GuardTower1); !hasEffect(tower.Effects, EffectStun) {
+		t.Fatalf("%s effects = %v, want a stun", tower.Name, tower.Effects)
+	}
+}
@@ -0,0 +1,114 @@
+// Package game implements the timeout tiebreaker chain
+package game
+
+// Timeout tiebreakers, each also the GAME_END reason when it decides the match
+const (
+	TiebreakTowersLost    = "towers_lost"     // Fewer towers lost wins
+	TiebreakLowestTowerHP = "lowest_tower_hp" // Higher HP % on the weakest standing tower wins
+	TiebreakTotalTowerHP  = "total_tower_hp"  // Higher HP % over all towers wins
+)
+
+// tiebreakers is the set of known tiebreakers a ruleset can list
+var tiebreakers = map[string]bool{
+	TiebreakTowersLost:    true,
+	TiebreakLowestTowerHP: true,
+	TiebreakTotalTowerHP:  true,
+}
+
+// tiebreakResult is the outcome of the tiebreaker chain
+type tiebreakResult struct {
+	winner  string  // Player ID, or "draw"
+	reason  string  // Deciding tiebreaker, or EndDraw
+	player1 float64 // Player1's score on the deciding tiebreaker
+	player2 float64 // Player2's score on the deciding tiebreaker
+}
+
+// breakTie runs the ruleset's tiebreaker chain on the current towers
+func (ge *GameEngine) breakTie() tiebreakResult {
+	p1, p2 := &ge.gameState.Player1, &ge.gameState.Player2
+
+	for _, criterion := range ge.gameState.Rules.Tiebreakers {
+		score1, score2 := tiebreakScore(criterion, p1), tiebreakScore(criterion, p2)
+		if score1 == score2 {
+			continue
+		}
+
+		winner := p1.ID
+		if score2 > score1 {
+			winner = p2.ID
+		}
+		return tiebreakResult{winner: winner, reason: criterion, player1: score1, player2: score2}
+	}
+
+	return tiebreakResult{winner: "draw", reason: EndDraw}
+}
+
+// tiebreakScore rates a player's towers on one tiebreaker, higher is better
+func tiebreakScore(criterion string, player *Player) float64 {
+	switch criterion {
+	case TiebreakTowersLost:
+		lost := 0
+		for _, tower := range player.Towers {
+			if tower.HP <= 0 {
+				lost++
+			}
+		}
+		return -float64(lost)
+
+	case TiebreakLowestTowerHP:
+		lowest := 100.0
+		for _, tower := range player.Towers {
+			if tower.HP > 0 && tower.MaxHP > 0 {
+				lowest = min(lowest, towerHPPercent(tower.HP, tower.MaxHP))
+			}
+		}
+		return lowest
+
+	case TiebreakTotalTowerHP:
+		hp, maxHP := 0, 0
+		for _, tower := range player.Towers {
+			hp += max(tower.HP, 0)
+			maxHP += tower.MaxHP
+		}
+		if maxHP == 0 {
+			return 0
+		}
+		return towerHPPercent(hp, maxHP)
+	}
+
+	return 0
+}
+
+// towerHPPercent rounds to a tenth of a percent, closer results count as tied
+func towerHPPercent(hp, maxHP int) float64 {
+	return float64(hp*1000/maxHP) / 10
+}
+
+// DescribeEndReason explains a GAME_END reason to the players
+func DescribeEndReason(reason string) string {
+	switch reason {
+	case EndKingTowerDestroyed:
+		return "King Tower destroyed"
+	case EndSurrender:
+		return "a player surrendered"
+	case EndTurnTimeouts:
+		return "a player let the turn clock run out too many times"
+	case EndTimeForfeit:
+		return "a player ran out of time on their chess clock"
+	case EndMoveDeadline:
+		return "a player missed the correspondence move deadline"
+	case EndDisconnect:
+		return "a player disconnected and did not come back in time"
+	case EndSuddenDeath:
+		return "first tower destroyed in overtime"
+	case TiebreakTowersLost:
+		return "time up, fewer towers lost"
+	case TiebreakLowestTowerHP:
+		return "time up, towers lost tied, weakest tower had more HP left"
+	case TiebreakTotalTowerHP:
+		return "time up, towers lost and weakest tower tied, more total tower HP left"
+	case EndDraw:
+		return "time up, still tied after every tiebreaker"
+	}
+	return reason
+}
@@ -0,0 +1,153 @@
+package game
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBreakTie(t *testing.T) {
+	// setHP sets one tower's HP, keyed by player number
+	type setHP struct {
+		player int
+		tower  TowerType
+		hp     int
+	}
+
+	tests := []struct {
+		name        string
+		tiebreakers []string // The enhanced ruleset's chain when empty
+		towers      []setHP
+		winner      int // Player number, 0 for a draw
+		reason      string
+	}{
+		{
+			name:   "towers lost decides even with a weaker tower",
+			towers: []setHP{{1, GuardTower1, 100}, {2, GuardTower1, 0}},
+			winner: 1,
+			reason: TiebreakTowersLost,
+		},
+		{
+			name:   "lowest tower hp decides when towers lost are tied",
+			towers: []setHP{{1, GuardTower1, 400}, {2, GuardTower1, 600}},
+			winner: 2,
+			reason: TiebreakLowestTowerHP,
+		},
+		{
+			name:   "total tower hp decides when the weakest towers are tied",
+			towers: []setHP{{1, GuardTower1, 400}, {2, GuardTower1, 400}, {2, GuardTower2, 700}},
+			winner: 1,
+			reason: TiebreakTotalTowerHP,
+		},
+		{
+			name:        "chain without lowest tower hp goes on to total tower hp",
+			tiebreakers: []string{TiebreakTowersLost, TiebreakTotalTowerHP},
+			towers:      []setHP{{1, GuardTower1, 400}, {2, GuardTower1, 600}},
+			winner:      2,
+			reason:      TiebreakTotalTowerHP,
+		},
+		{
+			name:   "tied on every tiebreaker is a draw",
+			towers: []setHP{{1, GuardTower2, 0}, {2, GuardTower1, 0}},
+			reason: EndDraw,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ge := newTestEngine(t, ModeEnhanced, 3)
+			if len(tt.tiebreakers) > 0 {
+				ge.gameState.Rules.Tiebreakers = tt.tiebreakers
+			}
+			players := []*Player{&ge.gameState.Player1, &ge.gameState.Player2}
+			for _, set := range tt.towers {
+				findTower(players[set.player-1], set.tower).HP = set.hp
+			}
+
+			result := ge.breakTie()
+
+			winner := "draw"
+			if tt.winner > 0 {
+				winner = players[tt.winner-1].ID
+			}
+			if result.winner != winner || result.reason != tt.reason {
+				t.Fatalf("breakTie() = %s by %s (%.1f vs %.1f), want %s by %s",
+					result.winner, result.reason, result.player1, result.player2, winner, tt.reason)
+			}
+		})
+	}
+}
+
+func TestTimeoutTieGoesToOvertime(t *testing.T) {
+	ge, clock, recording := startMatch(t, ModeEnhanced, matchOptions{seed: 3, clock: true, record: true})
+
+	// Nobody plays, so regular time ends tied on every tiebreaker
+	rules := ge.GetGameState().Rules
+	clock.Advance(time.Duration(rules.DurationSeconds+1) * time.Second)
+	state := ge.GetGameState()
+	if !ge.IsRunning() || state.Phase != PhaseOvertime {
+		t.Fatalf("after regular time: running = %v, phase = %s, want overtime", ge.IsRunning(), state.Phase)
+	}
+
+	for elapsed := 0; elapsed <= rules.OvertimeSeconds+1 && ge.IsRunning(); elapsed++ {
+		clock.Advance(time.Second)
+	}
+	if ge.IsRunning() {
+		t.Fatal("match still running after overtime")
+	}
+	<-ge.loopDone
+
+	if winner := ge.GetGameState().Winner; winner != "draw" {
+		t.Fatalf("winner = %q, want a draw", winner)
+	}
+	replay, err := ReadReplay(recording)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reason := gameEndReason(replay); reason != EndDraw {
+		t.Fatalf("GAME_END reason = %q, want %q", reason, EndDraw)
+	}
+}
+
+// gameEndReason returns the reason of the recorded GAME_END event
+func gameEndReason(replay *Replay) string {
+	for _, entry := range replay.Events {
+		if entry.Event.Type == "GAME_END" {
+			reason, _ := entry.Event.Data["reason"].(string)
+			return reason
+		}
+	}
+	return ""
+}
+
+func TestValidateTiebreakers(t *testing.T) {
+	tests := []struct {
+		name        string
+		tiebreakers []string
+		err         string
+	}{
+		{"towers lost first", []string{TiebreakTowersLost, TiebreakLowestTowerHP, TiebreakTotalTowerHP}, ""},
+		{"towers lost only", []string{TiebreakTowersLost}, ""},
+		{"empty", nil, `tiebreakers must start with "towers_lost"`},
+		{"hp before towers lost", []string{TiebreakLowestTowerHP, TiebreakTowersLost}, `tiebreakers must start with "towers_lost"`},
+		{"unknown tiebreaker", []string{TiebreakTowersLost, "king_hp"}, `unknown tiebreaker "king_hp"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules := newTestEngine(t, ModeEnhanced, 3).gameState.Rules
+			rules.Tiebreakers = tt.tiebreakers
+
+			err := rules.Validate(5)
+			if tt.err == "" {
+				if err != nil {
+					t.Fatalf("Validate() = %v, want ok", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Fatalf("Validate() = %v, want %s", err, tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,167 @@
+// Package game implements tower defensive fire (Enhanced mode)
+package game
+
+import "fmt"
+
+// ValidateTowers checks every tower has a usable hit speed
+func ValidateTowers(towerSpecs map[TowerType]TowerSpec) error {
+	for name, spec := range towerSpecs {
+		if spec.HitSpeed <= 0 {
+			return fmt.Errorf("tower %s: hit_speed must be positive", name)
+		}
+	}
+	return nil
+}
+
+// reloadTicks converts a tower's hit speed to ticks between shots
+func reloadTicks(tower *Tower) int {
+	return secondsToTicks(tower.HitSpeed)
+}
+
+// reloadTowers readies every tower for its first lock-on
+func reloadTowers(players ...*Player) {
+	for _, player := range players {
+		for i := range player.Towers {
+			player.Towers[i].Reload = reloadTicks(&player.Towers[i])
+		}
+	}
+}
+
+// fireTowers gives every tower on the field its chance to shoot this tick
+func (ge *GameEngine) fireTowers() {
+	ge.fireTowersOf(&ge.gameState.Player1, &ge.gameState.Player2)
+	ge.fireTowersOf(&ge.gameState.Player2, &ge.gameState.Player1)
+}
+
+// fireTowersOf counts down the owner's tower reloads and fires the ones that
+// are ready. A tower locks on when an enemy troop arrives in a lane it
+// defends and fires one hit speed later, then every hit speed while it has a
+// target. An idle tower stays reloaded; a dormant or stunned one holds its fire.
+func (ge *GameEngine) fireTowersOf(owner, enemy *Player) {
+	for i := range owner.Towers {
+		if !ge.isRunning {
+			return
+		}
+
+		tower := &owner.Towers[i]
+		target := towerTarget(owner, enemy, tower)
+		if target == nil {
+			tower.Reload = reloadTicks(tower)
+			continue
+		}
+
+		if !tower.IsActive || hasEffect(tower.Effects, EffectStun) {
+			continue
+		}
+
+		if tower.Reload > 0 {
+			tower.Reload--
+		}
+		if tower.Reload > 0 {
+			continue
+		}
+
+		tower.Reload = reloadTicks(tower)
+		ge.broadcastAction(ge.executeTowerShot(owner, enemy, tower, target))
+	}
+}
+
+// towerTarget returns the weakest enemy troop in the lanes the tower defends:
+// a Guard Tower covers its own lane and the King Tower every open lane.
+// Nil when the tower is down or has nothing to shoot at.
+func towerTarget(owner, enemy *Player, tower *Tower) *Troop {
+	if tower.HP <= 0 {
+		return nil
+	}
+
+	var target *Troop
+	for _, lane := range Lanes {
+		if laneTowerTarget(owner, lane) != tower {
+			continue
+		}
+		troop := laneTroopTarget(enemy, lane)
+		if troop != nil && (target == nil || troop.HP < target.HP) {
+			target = troop
+		}
+	}
+	return target
+}
+
+// executeTowerShot resolves one tower shot at an enemy troop
+func (ge *GameEngine) executeTowerShot(owner, enemy *Player, tower *Tower, target *Troop) CombatAction {
+	attackDamage := effectiveATK(tower.ATK, tower.Effects)
+	attackDamage, isCrit := ge.rollCrit(attackDamage, tower.CRIT)
+
+	damage := attackDamage - effectiveDEF(target.DEF, target.Effects)
+	if damage < 0 {
+		damage = 0
+	}
+	damage, absorbed := ge.absorbWithShield(troopCarrier(enemy, target), damage)
+
+	oldHP := target.HP
+	target.HP -= damage
+	if target.HP < 0 {
+		target.HP = 0
+	}
+
+	if damage > 0 {
+		ge.awardEXPForDamage(owner.ID, damage, "troop")
+	}
+
+	troopName := target.Name
+	if target.HP == 0 && oldHP > 0 {
+		ge.awardEXPForDestruction(owner.ID, "troop", troopName)
+
+		ge.logEvent("TROOP_DESTROYED", "", map[string]interface{}{
+			"destroyer":    owner.Username,
+			"troop_name":   troopName,
+			"troop_owner":  enemy.Username,
+			"final_damage": damage,
+		})
+
+		ge.broadcastAction(CombatAction{
+			Type:       "TROOP_DESTROYED",
+			PlayerID:   owner.ID,
+			TroopName:  TroopType(tower.Name),
+			TargetType: "troop",
+			TargetName: string(troopName),
+			Damage:     damage,
+			Timestamp:  ge.clock.Now(),
+			Data: map[string]interface{}{
+				"destroyer": owner.Username,
+				"owner":     enemy.Username,
+			},
+		})
+
+		ge.handleTroopDestroyed(enemy, target)
+	}
+
+	ge.logEvent("TOWER_SHOT", owner.ID, map[string]interface{}{
+		"attacker":     tower.Name,
+		"attacker_atk": tower.ATK,
+		"target":       troopName,
+		"target_def":   target.DEF,
+		"damage":       damage,
+		"target_hp":    target.HP,
+		"old_hp":       oldHP,
+		"is_crit":      isCrit,
+		"message":      fmt.Sprintf("%s fires at %s for %d damage!", tower.Name, troopName, damage),
+	})
+
+	return CombatAction{
+		Type:       ActionAttack,
+		PlayerID:   owner.ID,
+		TroopName:  TroopType(tower.Name),
+		TargetType: "troop",
+		TargetName: string(troopName),
+		Damage:     damage,
+		IsCrit:     isCrit,
+		Timestamp:  ge.clock.Now(),
+		Data: map[string]interface{}{
+			"target_hp":       target.HP,
+			"old_hp":          oldHP,
+			"tower_shot":      true,
+			"shield_absorbed": absorbed,
+		},
+	}
+}
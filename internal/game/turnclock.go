@@ -0,0 +1,137 @@
+// Package game implements the Simple mode turn clock and chess clock
+package game
+
+// Why a Simple mode turn ended, reported in the TURN_END event
+const (
+	TurnEndedByPlayer  = "ended"   // The player ended the turn
+	TurnEndedByTimeout = "timeout" // The turn clock ran out
+)
+
+// turnClockOn reports whether Simple mode turns are timed
+func (ge *GameEngine) turnClockOn() bool {
+	return ge.gameState.GameMode == ModeSimple && ge.gameState.Rules.TurnSeconds > 0
+}
+
+// timeBankOn reports whether Simple mode players play on a chess clock
+func (ge *GameEngine) timeBankOn() bool {
+	return ge.gameState.GameMode == ModeSimple && ge.gameState.Rules.BankSeconds > 0
+}
+
+// clockDriven reports whether the simulation loop runs on ticks
+func (ge *GameEngine) clockDriven() bool {
+	return ge.gameState.Rules.ClockDriven()
+}
+
+// resetTurnClock starts timing a new turn and gives the player to move a
+// full turn clock
+func (ge *GameEngine) resetTurnClock() {
+	ge.turnStart = ge.tick
+	if ge.turnClockOn() {
+		ge.gameState.TurnTimeLeft = ge.gameState.Rules.TurnSeconds
+	}
+}
+
+// tickTurnClock runs every tick: it forfeits the player to move as soon as
+// their chess clock is empty, and once per simulated second of the turn shows
+// what is left of it and runs the turn clock down, ending the turn when it
+// runs out
+func (ge *GameEngine) tickTurnClock() {
+	elapsed := ge.tick - ge.turnStart
+	if elapsed <= 0 {
+		return
+	}
+
+	if ge.timeBankOn() && ge.tickTimeBank(elapsed) {
+		return
+	}
+	if !ge.turnClockOn() || elapsed%TickRate != 0 {
+		return
+	}
+
+	ge.gameState.TurnTimeLeft--
+	if ge.gameState.TurnTimeLeft <= 0 {
+		ge.gameState.TurnTimeLeft = 0
+		ge.turnTimedOut()
+	}
+}
+
+// turnTimedOut ends the turn of the player to move, who forfeits the match
+// after TimeoutsToForfeit timeouts in a row
+func (ge *GameEngine) turnTimedOut() {
+	playerID := ge.gameState.CurrentTurn
+	player := ge.getPlayer(playerID)
+	if player == nil {
+		return
+	}
+
+	player.TurnTimeouts++
+	ge.logger.Info("Turn clock ran out for %s (%d in a row)", player.Username, player.TurnTimeouts)
+
+	if limit := ge.gameState.Rules.TimeoutsToForfeit; limit > 0 && player.TurnTimeouts >= limit {
+		ge.forfeit(playerID, EndTurnTimeouts)
+		return
+	}
+
+	ge.finishTurn(playerID, TurnEndedByTimeout)
+}
+
+// fillTimeBanks starts both players' chess clocks full
+func (ge *GameEngine) fillTimeBanks() {
+	if !ge.timeBankOn() {
+		return
+	}
+	ge.bankTicks = make(map[string]int, 2)
+	for _, player := range []*Player{&ge.gameState.Player1, &ge.gameState.Player2} {
+		ge.bankTicks[player.ID] = ge.gameState.Rules.BankSeconds * TickRate
+		player.TimeBank = ge.gameState.Rules.BankSeconds
+	}
+}
+
+// bankSeconds shows a chess clock in ticks as whole seconds, rounded up so
+// that 0 only shows once the clock is empty
+func bankSeconds(ticks int) int {
+	if ticks <= 0 {
+		return 0
+	}
+	return (ticks + TickRate - 1) / TickRate
+}
+
+// tickTimeBank checks the chess clock of the player to move, elapsed ticks
+// into their turn, and shows what is left of it once per second. Returns true
+// when their bank ran out and they forfeited the match.
+func (ge *GameEngine) tickTimeBank(elapsed int) bool {
+	playerID := ge.gameState.CurrentTurn
+	player := ge.getPlayer(playerID)
+	if player == nil {
+		return false
+	}
+
+	left := ge.bankTicks[playerID] - elapsed
+	if left > 0 {
+		if elapsed%TickRate == 0 {
+			player.TimeBank = bankSeconds(left)
+		}
+		return false
+	}
+
+	ge.bankTicks[playerID] = 0
+	player.TimeBank = 0
+	ge.logger.Info("Chess clock ran out for %s", player.Username)
+	ge.forfeit(playerID, EndTimeForfeit)
+	return true
+}
+
+// chargeTimeBank takes the ticks a player spent on the turn they just
+// finished off their chess clock, then adds the increment
+func (ge *GameEngine) chargeTimeBank(player *Player) {
+	if !ge.timeBankOn() || player == nil {
+		return
+	}
+	ticks := ge.bankTicks[player.ID] - (ge.tick - ge.turnStart)
+	if ticks < 0 {
+		ticks = 0
+	}
+	ticks += ge.gameState.Rules.IncrementSeconds * TickRate
+	ge.bankTicks[player.ID] = ticks
+	player.TimeBank = bankSeconds(ticks)
+}
@@ -0,0 +1,202 @@
+package game
+
+import (
+	"bytes"
+	"testing"
+)
+
+// advanceSeconds moves the fake clock on tick by tick
+func advanceSeconds(clock *FakeClock, seconds int) {
+	for i := 0; i < seconds*TickRate; i++ {
+		clock.Advance(TickDuration)
+	}
+}
+
+// finishedReplay waits for the match loop to exit and reads back its recording
+func finishedReplay(t *testing.T, ge *GameEngine, recording *bytes.Buffer) *Replay {
+	t.Helper()
+
+	if ge.IsRunning() {
+		t.Fatal("match is still running")
+	}
+	<-ge.loopDone
+	replay, err := ReadReplay(recording)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return replay
+}
+
+// turnEndReasons lists the reason of every recorded TURN_END event
+func turnEndReasons(replay *Replay) []string {
+	var reasons []string
+	for _, entry := range replay.Events {
+		if entry.Event.Type == "TURN_END" {
+			reason, _ := entry.Event.Data["reason"].(string)
+			reasons = append(reasons, reason)
+		}
+	}
+	return reasons
+}
+
+func TestTurnClockEndsTurnAndForfeits(t *testing.T) {
+	ge, clock, recording := startMatch(t, "timed", matchOptions{seed: 5, clock: true, record: true})
+	state := ge.GetGameState()
+	first, second := state.CurrentTurn, state.Player2.ID
+	if first == second {
+		second = state.Player1.ID
+	}
+	turnSeconds, limit := state.Rules.TurnSeconds, state.Rules.TimeoutsToForfeit
+
+	// One second short of the limit the turn is still running
+	advanceSeconds(clock, turnSeconds-1)
+	if state := ge.GetGameState(); state.CurrentTurn != first || state.TurnTimeLeft != 1 {
+		t.Fatalf("before the limit: turn = %s with %ds left, want %s with 1s", state.CurrentTurn, state.TurnTimeLeft, first)
+	}
+
+	advanceSeconds(clock, 1)
+	state = ge.GetGameState()
+	if state.CurrentTurn != second || state.TurnTimeLeft != turnSeconds {
+		t.Fatalf("after the limit: turn = %s with %ds left, want %s with a full %ds", state.CurrentTurn, state.TurnTimeLeft, second, turnSeconds)
+	}
+	if timeouts := playerState(ge, first).TurnTimeouts; timeouts != 1 {
+		t.Fatalf("%s has %d timeouts, want 1", first, timeouts)
+	}
+
+	// Both players keep letting the clock run out, the first to reach the
+	// limit forfeits
+	for turn := 1; turn < 2*limit && ge.IsRunning(); turn++ {
+		advanceSeconds(clock, turnSeconds)
+	}
+
+	replay := finishedReplay(t, ge, recording)
+	if winner := ge.GetGameState().Winner; winner != second {
+		t.Fatalf("winner = %q, want %s after %s timed out %d times", winner, second, first, limit)
+	}
+	if reason := gameEndReason(replay); reason != EndTurnTimeouts {
+		t.Fatalf("GAME_END reason = %q, want %q", reason, EndTurnTimeouts)
+	}
+	reasons := turnEndReasons(replay)
+	if len(reasons) != 2*limit-2 {
+		t.Fatalf("%d TURN_END events, want %d: %v", len(reasons), 2*limit-2, reasons)
+	}
+	for _, reason := range reasons {
+		if reason != TurnEndedByTimeout {
+			t.Fatalf("TURN_END reasons = %v, want every one %q", reasons, TurnEndedByTimeout)
+		}
+	}
+}
+
+func TestEndingTurnResetsTimeouts(t *testing.T) {
+	ge, clock, _ := startMatch(t, "timed", matchOptions{seed: 5, clock: true, record: true})
+	defer ge.StopGame()
+	first := ge.GetGameState().CurrentTurn
+	turnSeconds := ge.GetGameState().Rules.TurnSeconds
+
+	// first times out, then plays a turn on time
+	advanceSeconds(clock, 2*turnSeconds)
+	if err := ge.EndTurn(first); err != nil {
+		t.Fatal(err)
+	}
+	if timeouts := playerState(ge, first).TurnTimeouts; timeouts != 0 {
+		t.Fatalf("%s has %d timeouts after ending a turn, want 0", first, timeouts)
+	}
+}
+
+func TestTimeBankIncrement(t *testing.T) {
+	ge, clock, _ := startMatch(t, "tournament", matchOptions{seed: 5, clock: true, record: true})
+	defer ge.StopGame()
+	first := ge.GetGameState().CurrentTurn
+	rules := ge.GetGameState().Rules
+
+	advanceSeconds(clock, 10)
+	if bank := playerState(ge, first).TimeBank; bank != rules.BankSeconds-10 {
+		t.Fatalf("time bank = %ds after 10s of thinking, want %ds", bank, rules.BankSeconds-10)
+	}
+
+	if err := ge.EndTurn(first); err != nil {
+		t.Fatal(err)
+	}
+	want := rules.BankSeconds - 10 + rules.IncrementSeconds
+	if bank := playerState(ge, first).TimeBank; bank != want {
+		t.Fatalf("time bank = %ds after ending the turn, want %ds with the increment", bank, want)
+	}
+
+	// Only the player to move is on the clock
+	advanceSeconds(clock, 10)
+	if bank := playerState(ge, first).TimeBank; bank != want {
+		t.Fatalf("time bank = %ds on the opponent's turn, want it to stay %ds", bank, want)
+	}
+	second := ge.GetGameState().CurrentTurn
+	if err := ge.EndTurn(second); err != nil {
+		t.Fatal(err)
+	}
+
+	// Turns ended part way through a second are charged every tick they took
+	ticks := want * TickRate
+	for _, elapsed := range []int{5, 15} {
+		for i := 0; i < elapsed; i++ {
+			clock.Advance(TickDuration)
+		}
+		if err := ge.EndTurn(first); err != nil {
+			t.Fatal(err)
+		}
+		ticks += rules.IncrementSeconds*TickRate - elapsed
+		if bank := bankTicks(t, ge, first); bank != ticks {
+			t.Fatalf("time bank = %d ticks after a %d tick turn, want %d", bank, elapsed, ticks)
+		}
+		if bank := playerState(ge, first).TimeBank; bank != bankSeconds(ticks) {
+			t.Fatalf("time bank shows %ds, want %ds", bank, bankSeconds(ticks))
+		}
+		if err := ge.EndTurn(second); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// bankTicks reads a player's chess clock in ticks on the match loop
+func bankTicks(t *testing.T, ge *GameEngine, playerID string) int {
+	t.Helper()
+
+	var ticks int
+	if _, err := ge.submit(func() (*CombatAction, error) {
+		ticks = ge.bankTicks[playerID]
+		return nil, nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	return ticks
+}
+
+func TestTimeBankForfeit(t *testing.T) {
+	// A bank shorter than the turn clock runs out first
+	ge, clock, recording := startMatch(t, "tournament", matchOptions{seed: 5, clock: true, record: true, adjust: func(rules *Ruleset) {
+		rules.BankSeconds = 20
+	}})
+	state := ge.GetGameState()
+	first, second := state.CurrentTurn, state.Player2.ID
+	if first == second {
+		second = state.Player1.ID
+	}
+
+	advanceSeconds(clock, 19)
+	if !ge.IsRunning() || playerState(ge, first).TimeBank != 1 {
+		t.Fatalf("one second before the bank runs out: running = %v, bank = %ds", ge.IsRunning(), playerState(ge, first).TimeBank)
+	}
+
+	advanceSeconds(clock, 1)
+	replay := finishedReplay(t, ge, recording)
+	state = ge.GetGameState()
+	if state.Winner != second {
+		t.Fatalf("winner = %q, want %s after %s ran out of time", state.Winner, second, first)
+	}
+	if bank := playerState(ge, first).TimeBank; bank != 0 {
+		t.Fatalf("time bank = %ds, want 0", bank)
+	}
+	if reason := gameEndReason(replay); reason != EndTimeForfeit {
+		t.Fatalf("GAME_END reason = %q, want %q", reason, EndTimeForfeit)
+	}
+	if reasons := turnEndReasons(replay); len(reasons) != 0 {
+		t.Fatalf("TURN_END events %v, the forfeit should end the match without one", reasons)
+	}
+}
@@ -23,42 +23,95 @@ const (
 	GuardTower2 TowerType = "Guard Tower 2"
 )
 
+type SpellType string
+
+// SpellType represents the type and name of spell
+const (
+	Fireball SpellType = "Fireball"
+	Freeze   SpellType = "Freeze"
+	Rage     SpellType = "Rage"
+)
+
 type Troop struct {
-	Name    TroopType `json:"name"`
-	HP      int       `json:"hp"`
-	ATK     int       `json:"atk"`
-	DEF     int       `json:"def"`
-	CRIT    float64   `json:"crit"` // Crit chance as percentage (E.g : 10% = 0.10)
-	MANA    int       `json:"mana"`
-	MaxHP   int       `json:"max_hp"`
-	EXP     int       `json:"exp"`
-	Special string    `json:"special,omitempty"`
-	Level   int       `json:"level"`
+	Name      TroopType      `json:"name"`
+	HP        int            `json:"hp"`
+	ATK       int            `json:"atk"`
+	DEF       int            `json:"def"`
+	CRIT      float64        `json:"crit"` // Crit chance as percentage (E.g : 10% = 0.10)
+	MANA      int            `json:"mana"`
+	MaxHP     int            `json:"max_hp"`
+	EXP       int            `json:"exp"`
+	Special   string         `json:"special,omitempty"`
+	Level     int            `json:"level"`
+	HitSpeed  float64        `json:"hit_speed,omitempty"`  // Seconds between attacks on the field (Enhanced mode)
+	MoveSpeed float64        `json:"move_speed,omitempty"` // Tiles per second walked down the lane (Enhanced mode)
+	Travel    int            `json:"travel,omitempty"`     // Ticks left before it reaches the fight, 0 once there
+	Deployed  bool           `json:"deployed,omitempty"`   // On the field, enemy troops can target it
+	Lane      Lane           `json:"lane,omitempty"`       // Lane the troop was deployed in
+	Effects   []StatusEffect `json:"effects,omitempty"`    // Active status effects, e.g. shield or stun
+}
+
+// IsSupport reports whether the troop only acts through its abilities (e.g. Queen).
+// Support troops have no HP of their own, so they never attack or get attacked.
+func (t Troop) IsSupport() bool {
+	return t.MaxHP == 0
 }
 
 type Tower struct {
-	Name     TowerType `json:"name"`
-	HP       int       `json:"hp"`
-	MaxHP    int       `json:"max_hp"`
-	ATK      int       `json:"atk"`
-	DEF      int       `json:"def"`
-	CRIT     float64   `json:"crit"` // Crit chance as percentage (E.g : 10% = 0.10)
-	EXP      int       `json:"exp"`
-	Level    int       `json:"level"`
-	IsActive bool      `json:"is_active"` // For targeting rules
+	Name     TowerType      `json:"name"`
+	HP       int            `json:"hp"`
+	MaxHP    int            `json:"max_hp"`
+	ATK      int            `json:"atk"`
+	DEF      int            `json:"def"`
+	CRIT     float64        `json:"crit"` // Crit chance as percentage (E.g : 10% = 0.10)
+	EXP      int            `json:"exp"`
+	Level    int            `json:"level"`
+	IsActive bool           `json:"is_active"`        // False while a King Tower is dormant; only active towers fire
+	HitSpeed float64        `json:"hit_speed"`        // Seconds between shots
+	Reload   int            `json:"reload,omitempty"` // Ticks until the tower can fire at its target
+	Effects  []StatusEffect `json:"effects,omitempty"`
+}
+
+// StatusEffect is a timed effect on a troop or tower. It counts down one step
+// per second in Enhanced mode and per owner's turn in Simple mode.
+type StatusEffect struct {
+	Type      string    `json:"type"`                // "stun", "slow", "poison" or "shield"
+	Source    TroopType `json:"source,omitempty"`    // Troop or spell that applied it
+	SourceID  string    `json:"source_id,omitempty"` // Player that applied it, credited for poison damage
+	ATKMod    int       `json:"atk_mod,omitempty"`   // Added to ATK while active
+	DEFMod    int       `json:"def_mod,omitempty"`   // Added to DEF while active
+	Damage    int       `json:"damage,omitempty"`    // Damage dealt every step
+	Shield    int       `json:"shield,omitempty"`    // Damage left to absorb
+	Remaining int       `json:"remaining"`           // Steps left, 0 lasts until depleted or cleared
+}
+
+// Spell is a spell card a player can cast. Spells act once and never enter the field.
+type Spell struct {
+	Name         SpellType `json:"name"`
+	MANA         int       `json:"mana"`
+	Target       string    `json:"target"` // "enemy_tower" or "own_troops"
+	Description  string    `json:"description,omitempty"`
+	EnhancedOnly bool      `json:"enhanced_only,omitempty"` // Can't be cast in Simple mode
 }
 
 type Player struct {
-	ID                     string  `json:"id"`
-	Username               string  `json:"username"`
-	Password               string  `json:"password,omitempty"` // Omit in network messages
-	Level                  int     `json:"level"`
-	EXP                    int     `json:"exp"`
-	Mana                   int     `json:"mana"`
-	MaxMana                int     `json:"max_mana"`
-	Troops                 []Troop `json:"troops"` // 3 random troops for this match
-	Towers                 []Tower `json:"towers"` // 3 towers: 1 King + 2 Guard
-	TroopsDeployedThisTurn int     `json:"troops_deployed_this_turn"`
+	ID                     string      `json:"id"`
+	Username               string      `json:"username"`
+	Password               string      `json:"password,omitempty"` // Omit in network messages
+	Level                  int         `json:"level"`
+	EXP                    int         `json:"exp"`
+	Mana                   int         `json:"mana"`
+	MaxMana                int         `json:"max_mana"`
+	Troops                 []Troop     `json:"troops"`              // The match deck, one troop per card
+	Hand                   []TroopType `json:"hand,omitempty"`      // Cards that can be played now
+	NextCard               TroopType   `json:"next_card,omitempty"` // Card drawn into the hand when one is played
+	Queue                  []TroopType `json:"queue,omitempty"`     // Cards waiting behind NextCard
+	Towers                 []Tower     `json:"towers"`              // 3 towers: 1 King + 2 Guard
+	Spells                 []Spell     `json:"spells,omitempty"`
+	TroopsDeployedThisTurn int         `json:"troops_deployed_this_turn"` // Troops and spells played this turn (Simple mode)
+	TurnTimeouts           int         `json:"turn_timeouts,omitempty"`   // Turns in a row lost to the turn clock (Simple mode)
+	TimeBank               int         `json:"time_bank,omitempty"`       // Seconds left on the player's chess clock, rounded up (Simple mode)
+	ActionPoints           int         `json:"action_points,omitempty"`   // Action points left this turn (Simple mode)
 }
 
 type GameState struct {
@@ -67,14 +120,78 @@ type GameState struct {
 	Status       string    `json:"status"`    // "waiting", "active", "finished"
 	Player1      Player    `json:"player1"`
 	Player2      Player    `json:"player2"`
-	CurrentTurn  string    `json:"current_turn"` // Player ID (for Simple TCR)
-	TimeLeft     int       `json:"time_left"`    // Seconds remaining (for Enhanced TCR)
+	CurrentTurn  string    `json:"current_turn"`             // Player ID (for Simple TCR)
+	TimeLeft     int       `json:"time_left"`                // Seconds remaining (for Enhanced TCR)
+	TurnTimeLeft int       `json:"turn_time_left,omitempty"` // Seconds left on the turn clock (for Simple TCR)
+	Paused       bool      `json:"paused,omitempty"`         // A player is reconnecting, clocks and moves wait (for Simple TCR)
+	Rules        Ruleset   `json:"rules"`                    // Ruleset the match is played with
+	Phase        string    `json:"phase,omitempty"`          // Match phase (for Enhanced TCR)
 	StartTime    time.Time `json:"start_time"`
+	Seed         int64     `json:"seed"` // RNG seed for this match (crit rolls, troop draw)
 	Winner       string    `json:"winner,omitempty"`
 	TowersKilled struct {
 		Player1 int `json:"player1"`
 		Player2 int `json:"player2"`
 	} `json:"towers_killed"`
+	// Troops each side has on the field, listed on every copy handed out
+	Units struct {
+		Player1 []Unit `json:"player1"`
+		Player2 []Unit `json:"player2"`
+	} `json:"units"`
+}
+
+// Unit is a troop on the field, as listed in GameState for the client to render
+type Unit struct {
+	Name      TroopType `json:"name"`
+	Lane      Lane      `json:"lane"`
+	HP        int       `json:"hp"`
+	MaxHP     int       `json:"max_hp"`
+	ArrivesIn float64   `json:"arrives_in,omitempty"` // Seconds until it reaches the fight, 0 once there
+}
+
+// Clone returns a deep copy of the game state that is safe to hand to other goroutines
+func (gs *GameState) Clone() *GameState {
+	clone := *gs
+	clone.Player1 = gs.Player1.clone()
+	clone.Player2 = gs.Player2.clone()
+	clone.Units.Player1 = clone.Player1.FieldUnits()
+	clone.Units.Player2 = clone.Player2.FieldUnits()
+	return &clone
+}
+
+// FieldUnits lists the player's troops deployed on the field
+func (p Player) FieldUnits() []Unit {
+	units := make([]Unit, 0, len(p.Troops))
+	for _, troop := range p.Troops {
+		if !troop.Deployed || troop.HP <= 0 {
+			continue
+		}
+		units = append(units, Unit{
+			Name:      troop.Name,
+			Lane:      troop.Lane,
+			HP:        troop.HP,
+			MaxHP:     troop.MaxHP,
+			ArrivesIn: float64(troop.Travel) / TickRate,
+		})
+	}
+	return units
+}
+
+// clone copies a player together with its troop, tower, spell and card
+// slices and the effects they carry
+func (p Player) clone() Player {
+	p.Spells = append([]Spell(nil), p.Spells...)
+	p.Hand = append([]TroopType(nil), p.Hand...)
+	p.Queue = append([]TroopType(nil), p.Queue...)
+	p.Troops = append([]Troop(nil), p.Troops...)
+	for i := range p.Troops {
+		p.Troops[i].Effects = append([]StatusEffect(nil), p.Troops[i].Effects...)
+	}
+	p.Towers = append([]Tower(nil), p.Towers...)
+	for i := range p.Towers {
+		p.Towers[i].Effects = append([]StatusEffect(nil), p.Towers[i].Effects...)
+	}
+	return p
 }
 
 type CombatAction struct {
@@ -94,26 +211,47 @@ type CombatAction struct {
 type GameSpecs struct {
 	TroopSpecs map[TroopType]TroopSpec `json:"troops"`
 	TowerSpecs map[TowerType]TowerSpec `json:"towers"`
+	SpellSpecs map[SpellType]SpellSpec `json:"spells,omitempty"`
+	Version    string                  `json:"version"` // Content hash, recorded in replays
 }
 
 // TroopSpec defines base specifications for each troop type
 type TroopSpec struct {
-	HP      int     `json:"hp"`
-	ATK     int     `json:"atk"`
-	DEF     int     `json:"def"`
-	CRIT    float64 `json:"crit"`
-	MANA    int     `json:"mana"`
-	EXP     int     `json:"exp"`
-	Special string  `json:"special,omitempty"`
+	HP        int           `json:"hp"`
+	ATK       int           `json:"atk"`
+	DEF       int           `json:"def"`
+	CRIT      float64       `json:"crit"`
+	MANA      int           `json:"mana"`
+	EXP       int           `json:"exp"`
+	HitSpeed  float64       `json:"hit_speed,omitempty"`  // Seconds between attacks (Enhanced mode), support troops have none
+	MoveSpeed float64       `json:"move_speed,omitempty"` // Tiles per second walked down the lane (Enhanced mode)
+	Special   string        `json:"special,omitempty"`    // Human readable ability description
+	Abilities []AbilitySpec `json:"abilities,omitempty"`  // Abilities dispatched by the engine
+}
+
+// AbilitySpec declares a troop ability and the hook that fires it
+type AbilitySpec struct {
+	Type    string             `json:"type"`    // Registered ability, e.g. "heal_lowest_tower"
+	Trigger string             `json:"trigger"` // "on_summon", "on_attack" or "on_death"
+	Params  map[string]float64 `json:"params,omitempty"`
+}
+
+// SpellSpec defines a spell card and the registered effect it casts
+type SpellSpec struct {
+	MANA        int                `json:"mana"`
+	Effect      string             `json:"effect"` // Registered spell effect, e.g. "tower_damage"
+	Description string             `json:"description,omitempty"`
+	Params      map[string]float64 `json:"params,omitempty"`
 }
 
 // TowerSpec defines base specifications for each tower type
 type TowerSpec struct {
-	HP   int     `json:"hp"`
-	ATK  int     `json:"atk"`
-	DEF  int     `json:"def"`
-	CRIT float64 `json:"crit"`
-	EXP  int     `json:"exp"`
+	HP       int     `json:"hp"`
+	ATK      int     `json:"atk"`
+	DEF      int     `json:"def"`
+	CRIT     float64 `json:"crit"`
+	EXP      int     `json:"exp"`
+	HitSpeed float64 `json:"hit_speed"` // Seconds between shots
 }
 
 // PlayerData represents persistent player data
@@ -128,19 +266,58 @@ type PlayerData struct {
 	GamesWon    int               `json:"games_won"`
 	LastLogin   time.Time         `json:"last_login"`
 	IsActive    bool              `json:"is_active"`
+	Decks       []Deck            `json:"decks,omitempty"`
+	ActiveDeck  string            `json:"active_deck,omitempty"` // Deck used in matches, random troops when empty
+}
+
+// Deck is a named selection of troop cards the player owns
+type Deck struct {
+	Name  string      `json:"name"`
+	Cards []TroopType `json:"cards"`
+}
+
+// Ruleset holds the match rules of a game mode, loaded from rules.json.
+// Every match plays one ruleset; the GameState carries a copy of it.
+type Ruleset struct {
+	Name        string `json:"name"` // Key in rules.json
+	Mode        string `json:"mode"` // "simple" or "enhanced"
+	Description string `json:"description,omitempty"`
+
+	// Match clock (Enhanced mode)
+	DurationSeconds      int      `json:"duration_seconds,omitempty"`
+	DoubleManaSeconds    int      `json:"double_mana_seconds,omitempty"`    // Final seconds of regulation with faster mana, 0 turns it off
+	DoubleManaMultiplier int      `json:"double_mana_multiplier,omitempty"` // Regen multiplier in double mana and overtime
+	OvertimeSeconds      int      `json:"overtime_seconds,omitempty"`       // Sudden death played when towers lost are tied at timeout, 0 turns it off
+	Tiebreakers          []string `json:"tiebreakers,omitempty"`            // Tried in order at timeout, a match still tied is a draw
+
+	// Mana (Enhanced mode)
+	StartingMana       int `json:"starting_mana"`
+	MaxMana            int `json:"max_mana"`
+	ManaRegenPerSecond int `json:"mana_regen_per_second,omitempty"`
+
+	// Turns (Simple mode)
+	CardsPerTurn      int `json:"cards_per_turn,omitempty"`      // Troops and spells a player may play per turn
+	ActionPoints      int `json:"action_points,omitempty"`       // Budget for each turn that replaces cards_per_turn, 0 turns it off
+	TurnSeconds       int `json:"turn_seconds,omitempty"`        // Turn clock, the turn ends by itself when it runs out. 0 turns it off
+	TimeoutsToForfeit int `json:"timeouts_to_forfeit,omitempty"` // Consecutive turns lost to the clock that forfeit the match, 0 never
+	BankSeconds       int `json:"bank_seconds,omitempty"`        // Chess clock, each player's total thinking time. 0 turns it off
+	IncrementSeconds  int `json:"increment_seconds,omitempty"`   // Added to a player's time bank after each of their turns
+	MoveDeadlineHours int `json:"move_deadline_hours,omitempty"` // Correspondence play: the match is saved between turns and the player to move has this long. 0 plays live
+
+	CritMultiplier float64 `json:"crit_multiplier"` // Damage multiplier on a crit, 1 turns crits off
+	HandSize       int     `json:"hand_size"`       // Cards that can be played at once, the rest wait in the queue
+
+	WinEXP  int `json:"win_exp"`  // EXP for winning
+	LoseEXP int `json:"lose_exp"` // EXP for losing
+	DrawEXP int `json:"draw_exp"` // EXP for draw
 }
 
 // Game constants
 const (
-	// Enhanced TCR constants
-	GameDurationSeconds = 180 // 3 minutes
-	StartingMana        = 5
-	MaxMana             = 10
-	ManaRegenPerSecond  = 1
-
-	WinEXP  = 50 // EXP for winning
-	LoseEXP = 10 // EXP for losing
-	DrawEXP = 25 // EXP for draw
+	// Enhanced TCR simulation loop
+	TickRate     = 10                     // Simulation ticks per second
+	TickDuration = 100 * time.Millisecond // Fixed timestep (1s / TickRate)
+	LaneLength   = 6.0                    // Tiles a troop walks from its deploy point to the fight
 
 	TowerDestroyEXP = 100 // Extra EXP for destroying towers
 	TroopKillEXP    = 20  // Extra EXP for killing troops
@@ -150,18 +327,57 @@ const (
 	EXPScalePerLevel  = 0.15 // 15% increase in required EXP per level
 	BaseEXPRequired   = 100  // Base EXP needed for level 2
 
-	// Number of troops and towers
-	TroopsPerPlayer = 3
+	// Number of towers
 	TowersPerPlayer = 3 // 1 King + 2 Guard
+
+	// Saved decks
+	MaxDecksPerPlayer = 5
+	MaxDeckNameLength = 20
 )
 
 // ActionType constants
 const (
-	ActionSummon    = "summon"
-	ActionAttack    = "attack"
-	ActionHeal      = "heal"
-	ActionEndTurn   = "end_turn"
-	ActionSurrender = "surrender"
+	ActionSummon        = "summon"
+	ActionAttack        = "attack"
+	ActionHeal          = "heal"
+	ActionAbility       = "ability"
+	ActionEffectApplied = "effect_applied"
+	ActionEffectRemoved = "effect_removed"
+	ActionCastSpell     = "cast_spell"
+	ActionEndTurn       = "end_turn"
+	ActionSurrender     = "surrender"
+)
+
+// Match phase constants (Enhanced mode)
+const (
+	PhaseRegular    = "regular"
+	PhaseDoubleMana = "double_mana"
+	PhaseOvertime   = "overtime"
+)
+
+// Match phase event types, sent as GAME_EVENT when a phase starts
+const (
+	EventDoubleMana = "DOUBLE_MANA"
+	EventOvertime   = "OVERTIME"
+)
+
+// EventKingActivated is sent as GAME_EVENT when a dormant King Tower wakes up
+const EventKingActivated = "KING_ACTIVATED"
+
+// EventTroopArrived is sent as GAME_EVENT when a troop in transit reaches the fight
+const EventTroopArrived = "TROOP_ARRIVED"
+
+// Game end reasons reported in GAME_END. A match decided at timeout
+// reports the tiebreaker that decided it instead.
+const (
+	EndKingTowerDestroyed = "king_tower_destroyed"
+	EndSurrender          = "surrender"
+	EndSuddenDeath        = "sudden_death"
+	EndDraw               = "draw"
+	EndTurnTimeouts       = "turn_timeouts"
+	EndTimeForfeit        = "time_forfeit"
+	EndMoveDeadline       = "move_deadline"
+	EndDisconnect         = "disconnect"
 )
 
 // GameStatus constants
@@ -176,3 +392,7 @@ const (
 	ModeSimple   = "simple"
 	ModeEnhanced = "enhanced"
 )
+
+// QueueCorrespondence is the matchmaking queue for correspondence matches:
+// Simple mode games saved between turns that players come back to later
+const QueueCorrespondence = "correspondence"
@@ -18,14 +18,25 @@ const (
 	MsgAuthOK   MessageType = "AUTH_OK"
 	MsgAuthFail MessageType = "AUTH_FAIL"
 
+	// Deck messages
+	MsgSaveDeck    MessageType = "SAVE_DECK"
+	MsgSelectDeck  MessageType = "SELECT_DECK"
+	MsgDeckUpdated MessageType = "DECK_UPDATED"
+
 	// Matchmaking messages
 	MsgFindMatch    MessageType = "FIND_MATCH"
 	MsgMatchFound   MessageType = "MATCH_FOUND"
 	MsgGameStart    MessageType = "GAME_START"
 	MsgPlayerJoined MessageType = "PLAYER_JOINED"
 
+	// Correspondence messages
+	MsgListGames MessageType = "LIST_GAMES"
+	MsgOpenGame  MessageType = "OPEN_GAME"
+	MsgLeaveGame MessageType = "LEAVE_GAME"
+
 	// Game action messages
 	MsgSummonTroop MessageType = "SUMMON_TROOP"
+	MsgCastSpell   MessageType = "CAST_SPELL"
 	MsgAttack      MessageType = "ATTACK"
 	MsgEndTurn     MessageType = "END_TURN"
 	MsgSurrender   MessageType = "SURRENDER"
@@ -42,6 +53,10 @@ const (
 	MsgPong       MessageType = "PONG"
 	MsgDisconnect MessageType = "DISCONNECT"
 	MsgManaUpdate MessageType = "MANA_UPDATE"
+
+	// Reconnect messages, sent to the opponent of a player who dropped out
+	MsgPlayerAway MessageType = "PLAYER_AWAY"
+	MsgPlayerBack MessageType = "PLAYER_BACK"
 )
 
 // Message represents a network message between client and server
@@ -65,11 +80,28 @@ type AuthResponse struct {
 	PlayerID   string           `json:"player_id,omitempty"`
 	Message    string           `json:"message,omitempty"`
 	PlayerData *game.PlayerData `json:"player_data,omitempty"`
+	DeckSize   int              `json:"deck_size,omitempty"` // Troop cards in a deck
+}
+
+// SaveDeckRequest represents saving a named deck
+type SaveDeckRequest struct {
+	Deck game.Deck `json:"deck"`
+}
+
+// SelectDeckRequest represents picking the active deck, empty for random troops
+type SelectDeckRequest struct {
+	DeckName string `json:"deck_name"`
+}
+
+// DeckUpdatedResponse carries the player data after a deck change
+type DeckUpdatedResponse struct {
+	Message    string           `json:"message"`
+	PlayerData *game.PlayerData `json:"player_data"`
 }
 
 // MatchRequest represents a request to find a match
 type MatchRequest struct {
-	GameMode string `json:"game_mode"` // "simple" or "enhanced"
+	GameMode string `json:"game_mode"` // "simple", "enhanced" or "correspondence"
 }
 
 // MatchFoundResponse represents successful matchmaking
@@ -86,11 +118,33 @@ type GameStartResponse struct {
 	YourTroops       []game.Troop   `json:"your_troops"`
 	YourTowers       []game.Tower   `json:"your_towers"`
 	CountdownSeconds int            `json:"countdown_seconds"`
+	Resumed          bool           `json:"resumed,omitempty"` // An open correspondence match picked up again
+}
+
+// OpenGameRequest represents picking up an open correspondence match
+type OpenGameRequest struct {
+	GameID string `json:"game_id"`
+}
+
+// CorrespondenceGameInfo describes one of the player's open correspondence matches
+type CorrespondenceGameInfo struct {
+	GameID   string    `json:"game_id"`
+	Opponent string    `json:"opponent"`
+	Ruleset  string    `json:"ruleset"`
+	YourTurn bool      `json:"your_turn"`
+	Deadline time.Time `json:"deadline"` // The player to move forfeits when it passes
 }
 
 // SummonTroopRequest represents summoning a troop
 type SummonTroopRequest struct {
 	TroopName game.TroopType `json:"troop_name"`
+	Lane      game.Lane      `json:"lane,omitempty"` // "left" or "right", ignored for support troops
+}
+
+// CastSpellRequest represents casting a spell card
+type CastSpellRequest struct {
+	SpellName  game.SpellType `json:"spell_name"`
+	TargetName string         `json:"target_name,omitempty"` // Enemy tower, for "enemy_tower" spells
 }
 
 // AttackRequest represents an attack action
@@ -109,7 +163,7 @@ type GameEventResponse struct {
 // GameEndResponse represents game conclusion
 type GameEndResponse struct {
 	Winner       string    `json:"winner"`
-	Reason       string    `json:"reason"` // "king_tower_destroyed", "time_up", "surrender"
+	Reason       string    `json:"reason"` // "king_tower_destroyed", "surrender", "turn_timeouts", "time_forfeit", "move_deadline", "disconnect", "sudden_death", a timeout tiebreaker or "draw"
 	EXPGained    int       `json:"exp_gained"`
 	TrophyChange int       `json:"trophy_change"`
 	Stats        GameStats `json:"stats"`
@@ -190,11 +244,49 @@ func CreateMatchRequest(playerID, gameMode string) *Message {
 	return msg
 }
 
+// CreateOpenGameMessage creates the message picking up a correspondence match
+func CreateOpenGameMessage(playerID, gameID string) *Message {
+	msg := NewMessage(MsgOpenGame, playerID, "")
+	msg.SetData("open_game_request", OpenGameRequest{
+		GameID: gameID,
+	})
+	return msg
+}
+
+// CreateSaveDeckMessage creates deck saving message
+func CreateSaveDeckMessage(playerID string, deck game.Deck) *Message {
+	msg := NewMessage(MsgSaveDeck, playerID, "")
+	msg.SetData("save_deck_request", SaveDeckRequest{
+		Deck: deck,
+	})
+	return msg
+}
+
+// CreateSelectDeckMessage creates active deck selection message
+func CreateSelectDeckMessage(playerID, deckName string) *Message {
+	msg := NewMessage(MsgSelectDeck, playerID, "")
+	msg.SetData("select_deck_request", SelectDeckRequest{
+		DeckName: deckName,
+	})
+	return msg
+}
+
 // CreateSummonMessage creates troop summoning message
-func CreateSummonMessage(playerID, gameID string, troopName game.TroopType) *Message {
+func CreateSummonMessage(playerID, gameID string, troopName game.TroopType, lane game.Lane) *Message {
 	msg := NewMessage(MsgSummonTroop, playerID, gameID)
 	msg.SetData("summon_request", SummonTroopRequest{
 		TroopName: troopName,
+		Lane:      lane,
+	})
+	return msg
+}
+
+// CreateCastSpellMessage creates spell casting message
+func CreateCastSpellMessage(playerID, gameID string, spellName game.SpellType, targetName string) *Message {
+	msg := NewMessage(MsgCastSpell, playerID, gameID)
+	msg.SetData("cast_spell_request", CastSpellRequest{
+		SpellName:  spellName,
+		TargetName: targetName,
 	})
 	return msg
 }
@@ -0,0 +1,195 @@
+// Package server implements correspondence games: Simple mode matches that
+// stay open when the players log out and are played over hours or days
+package server
+
+import (
+	"fmt"
+	"time"
+
+	"tcr-game/internal/game"
+	"tcr-game/internal/network"
+)
+
+// handleListGames sends the player their open correspondence games
+func (s *Server) handleListGames(client *Client, msg *network.Message) error {
+	if client.Player == nil {
+		return s.sendError(client, "NOT_AUTHENTICATED", "Must login first")
+	}
+
+	openGames := make([]network.CorrespondenceGameInfo, 0)
+	for _, cg := range s.dataManager.CorrespondenceGames() {
+		if !cg.HasPlayer(client.Username) {
+			continue
+		}
+
+		opponent := cg.Players[0]
+		if opponent == client.Username {
+			opponent = cg.Players[1]
+		}
+
+		yourTurn := false
+		if gameEngine := s.getGame(cg.GameID); gameEngine != nil {
+			yourTurn = gameEngine.GetGameState().CurrentTurn == client.ID
+		}
+
+		openGames = append(openGames, network.CorrespondenceGameInfo{
+			GameID:   cg.GameID,
+			Opponent: opponent,
+			Ruleset:  cg.Ruleset,
+			YourTurn: yourTurn,
+			Deadline: cg.Deadline,
+		})
+	}
+
+	response := network.NewMessage(network.MsgListGames, client.ID, "")
+	response.SetData("open_games", openGames)
+	return s.sendMessage(client, response)
+}
+
+// handleOpenGame picks an open correspondence game back up and sends the
+// player its current state
+func (s *Server) handleOpenGame(client *Client, msg *network.Message) error {
+	if client.Player == nil {
+		return s.sendError(client, "NOT_AUTHENTICATED", "Must login first")
+	}
+	if client.GameID != "" {
+		return s.sendError(client, "ALREADY_IN_GAME", "Leave your current game first")
+	}
+
+	openReq, ok := msg.Data["open_game_request"].(map[string]interface{})
+	if !ok {
+		return s.sendError(client, "INVALID_REQUEST", "Invalid open game request format")
+	}
+	gameID, _ := openReq["game_id"].(string)
+
+	gameEngine := s.getGame(gameID)
+	if gameEngine == nil || !s.playsCorrespondenceGame(client, gameID) {
+		return s.sendError(client, "GAME_NOT_FOUND", "You have no open correspondence game with that ID")
+	}
+
+	s.mu.Lock()
+	client.GameID = gameID
+	s.mu.Unlock()
+
+	s.logger.Info("Player %s opened correspondence game %s", client.Username, gameID)
+	s.sendGameStartTo(client, gameEngine, true)
+	return nil
+}
+
+// handleLeaveGame sends the player back to the menu, their correspondence
+// game stays open
+func (s *Server) handleLeaveGame(client *Client, msg *network.Message) error {
+	gameEngine := s.getClientGame(client)
+	if gameEngine == nil {
+		return s.sendError(client, "NO_ACTIVE_GAME", "No active game found")
+	}
+	if !gameEngine.GetGameState().Rules.Correspondence() {
+		return s.sendError(client, "INVALID_ACTION", "Only correspondence games can be left and picked up later")
+	}
+
+	s.mu.Lock()
+	gameID := client.GameID
+	client.GameID = ""
+	s.mu.Unlock()
+
+	s.logger.Info("Player %s left correspondence game %s", client.Username, gameID)
+	response := network.NewMessage(network.MsgLeaveGame, client.ID, gameID)
+	return s.sendMessage(client, response)
+}
+
+// playsCorrespondenceGame reports whether the client is a player in the open
+// correspondence game
+func (s *Server) playsCorrespondenceGame(client *Client, gameID string) bool {
+	for _, cg := range s.dataManager.CorrespondenceGames() {
+		if cg.GameID == gameID {
+			return cg.HasPlayer(client.Username)
+		}
+	}
+	return false
+}
+
+// saveMoveDeadline stores a correspondence game with a fresh deadline for the
+// player to move
+func (s *Server) saveMoveDeadline(gameState *game.GameState) error {
+	return s.dataManager.SaveCorrespondenceGame(game.CorrespondenceGame{
+		GameID:   gameState.ID,
+		Players:  [2]string{gameState.Player1.Username, gameState.Player2.Username},
+		Ruleset:  gameState.Rules.Name,
+		Deadline: time.Now().Add(gameState.Rules.MoveDeadline()),
+		ToMove:   gameState.CurrentTurn,
+	})
+}
+
+// resumeCorrespondenceGames rebuilds the open correspondence games from their
+// replays. A game that can't be rebuilt is dropped.
+func (s *Server) resumeCorrespondenceGames() {
+	for _, cg := range s.dataManager.CorrespondenceGames() {
+		gameEngine, err := s.resumeCorrespondenceGame(cg.GameID)
+		if err != nil {
+			s.logger.Error("Dropping correspondence game %s: %v", cg.GameID, err)
+			s.dataManager.RemoveCorrespondenceGame(cg.GameID)
+			continue
+		}
+
+		s.mu.Lock()
+		s.games[cg.GameID] = gameEngine
+		s.mu.Unlock()
+
+		go s.handleGameEvents(gameEngine)
+	}
+}
+
+// resumeCorrespondenceGame re-runs one correspondence game from its replay
+// and keeps recording to it
+func (s *Server) resumeCorrespondenceGame(gameID string) (*game.GameEngine, error) {
+	replayDir := s.dataManager.ReplayDir()
+	replay, err := game.LoadReplay(game.ReplayPath(replayDir, gameID))
+	if err != nil {
+		return nil, err
+	}
+
+	recorder, err := game.ContinueReplayRecorder(replayDir, gameID, replay.LastSeq)
+	if err != nil {
+		return nil, err
+	}
+
+	gameEngine, err := game.ResumeGame(replay, s.dataManager, recorder)
+	if err != nil {
+		recorder.Close()
+		return nil, fmt.Errorf("failed to resume: %w", err)
+	}
+	return gameEngine, nil
+}
+
+// moveDeadlineService forfeits correspondence games whose player to move
+// missed the deadline
+func (s *Server) moveDeadlineService() {
+	ticker := time.NewTicker(1 * time.Minute)
+	defer ticker.Stop()
+
+	for s.isRunning {
+		<-ticker.C
+		s.checkMoveDeadlines()
+	}
+}
+
+func (s *Server) checkMoveDeadlines() {
+	now := time.Now()
+	for _, cg := range s.dataManager.CorrespondenceGames() {
+		if now.Before(cg.Deadline) {
+			continue
+		}
+
+		gameEngine := s.getGame(cg.GameID)
+		if gameEngine == nil {
+			continue
+		}
+
+		// Only the player the deadline was set for forfeits. If the turn has
+		// moved on the engine refuses, and the new deadline is being saved.
+		// The engine's GAME_END closes the game through endGame.
+		if err := gameEngine.ForfeitMoveDeadline(cg.ToMove); err != nil {
+			s.logger.Info("Not forfeiting %s in %s: %v", cg.ToMove, cg.GameID, err)
+		}
+	}
+}
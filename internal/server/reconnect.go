@@ -0,0 +1,185 @@
+// Package server implements the reconnect grace period: a player whose
+// connection drops mid-match can log back in and carry on before they forfeit
+package server
+
+import (
+	"time"
+
+	"tcr-game/internal/game"
+	"tcr-game/internal/network"
+)
+
+// DefaultReconnectGrace is how long a dropped player has to come back
+const DefaultReconnectGrace = 60 * time.Second
+
+// awayPlayer is a player who dropped out of a match and is waited for
+type awayPlayer struct {
+	gameID string
+	timer  *time.Timer // Forfeits the match when the grace period runs out
+}
+
+// SetReconnectGrace sets how long a player whose connection drops has to log
+// back in to their match. Zero ends the match as soon as they drop. Must be
+// called before Start.
+func (s *Server) SetReconnectGrace(grace time.Duration) {
+	s.reconnectGrace = grace
+	s.logger.Info("Dropped players have %v to reconnect", grace)
+}
+
+// startReconnectGrace waits for a dropped player to come back. Callers hold
+// s.mu, and call the returned func once they released it: it pauses a Simple
+// match meanwhile (an Enhanced match keeps running) and tells the opponent.
+func (s *Server) startReconnectGrace(gameEngine *game.GameEngine, playerID string) func() {
+	if _, away := s.awayPlayers[playerID]; away {
+		return nil
+	}
+
+	gameID := gameEngine.GetGameState().ID
+	away := &awayPlayer{gameID: gameID}
+	away.timer = time.AfterFunc(s.reconnectGrace, func() {
+		s.reconnectExpired(playerID, away)
+	})
+	s.awayPlayers[playerID] = away
+
+	return func() {
+		s.syncPause(gameEngine, gameID)
+		s.notifyPlayerAway(gameEngine, playerID)
+	}
+}
+
+// syncPause pauses the match while one of its players is away and resumes it
+// once none is. Callers must not hold s.mu, Pause and Resume wait on the
+// engine's loop. pauseMu keeps the calls in order, so the last one matches
+// the latest awayPlayers.
+func (s *Server) syncPause(gameEngine *game.GameEngine, gameID string) {
+	s.pauseMu.Lock()
+	defer s.pauseMu.Unlock()
+
+	s.mu.RLock()
+	away := s.hasAwayPlayer(gameID)
+	s.mu.RUnlock()
+
+	if away {
+		gameEngine.Pause()
+	} else {
+		gameEngine.Resume()
+	}
+}
+
+// notifyPlayerAway tells the opponent that the player dropped out and how
+// long they have to come back
+func (s *Server) notifyPlayerAway(gameEngine *game.GameEngine, playerID string) {
+	gameState := gameEngine.GetGameState()
+
+	username := gameState.Player1.Username
+	if gameState.Player2.ID == playerID {
+		username = gameState.Player2.Username
+	}
+	s.logger.Info("Player %s dropped out of %s, waiting %v for them", username, gameState.ID, s.reconnectGrace)
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, client := range s.clients {
+		if client.GameID == gameState.ID && client.ID != playerID && client.IsActive {
+			msg := network.NewMessage(network.MsgPlayerAway, client.ID, gameState.ID)
+			msg.SetData("player_away", map[string]interface{}{
+				"player":        username,
+				"grace_seconds": int(s.reconnectGrace.Seconds()),
+				"paused":        gameState.Paused,
+			})
+			s.sendMessage(client, msg)
+		}
+	}
+}
+
+// reconnectExpired forfeits the match for a player who did not come back in
+// time. The engine's GAME_END closes the game through endGame.
+func (s *Server) reconnectExpired(playerID string, away *awayPlayer) {
+	s.mu.Lock()
+	if s.awayPlayers[playerID] != away {
+		// They came back or the match is over
+		s.mu.Unlock()
+		return
+	}
+	delete(s.awayPlayers, playerID)
+	gameEngine := s.games[away.gameID]
+	s.mu.Unlock()
+
+	if gameEngine == nil {
+		return
+	}
+
+	s.logger.Info("Player %s did not reconnect to %s in time", playerID, away.gameID)
+	if err := gameEngine.ForfeitDisconnect(playerID); err != nil {
+		s.logger.Error("Failed to forfeit %s in %s: %v", playerID, away.gameID, err)
+	}
+}
+
+// reattachPlayer puts a player who just logged in back into the match they
+// dropped out of and returns it, or nil when there is none
+func (s *Server) reattachPlayer(client *Client) *game.GameEngine {
+	s.mu.Lock()
+	away, ok := s.awayPlayers[client.ID]
+	if !ok {
+		s.mu.Unlock()
+		return nil
+	}
+	away.timer.Stop()
+	delete(s.awayPlayers, client.ID)
+
+	gameEngine := s.games[away.gameID]
+	if gameEngine == nil {
+		s.mu.Unlock()
+		return nil
+	}
+	client.GameID = away.gameID
+	s.mu.Unlock()
+
+	// With both players gone the match waits for the second one too
+	s.syncPause(gameEngine, away.gameID)
+
+	s.logger.Info("Player %s rejoined game %s", client.Username, away.gameID)
+	return gameEngine
+}
+
+// notifyPlayerBack tells the opponent that the player is back in the match
+func (s *Server) notifyPlayerBack(client *Client, gameEngine *game.GameEngine) {
+	gameState := gameEngine.GetGameState()
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, other := range s.clients {
+		if other.GameID == gameState.ID && other.ID != client.ID && other.IsActive {
+			msg := network.NewMessage(network.MsgPlayerBack, other.ID, gameState.ID)
+			msg.SetData("player_back", map[string]interface{}{
+				"player": client.Username,
+				"paused": gameState.Paused,
+			})
+			s.sendMessage(other, msg)
+		}
+	}
+}
+
+// hasAwayPlayer reports whether a player of the game is still away. Callers
+// hold s.mu.
+func (s *Server) hasAwayPlayer(gameID string) bool {
+	for _, away := range s.awayPlayers {
+		if away.gameID == gameID {
+			return true
+		}
+	}
+	return false
+}
+
+// cancelReconnectGrace stops waiting for the players of a finished game.
+// Callers hold s.mu.
+func (s *Server) cancelReconnectGrace(gameID string) {
+	for playerID, away := range s.awayPlayers {
+		if away.gameID == gameID {
+			away.timer.Stop()
+			delete(s.awayPlayers, playerID)
+		}
+	}
+}
@@ -16,15 +16,19 @@ import (
 
 // Server represents the TCP server
 type Server struct {
-	address     string
-	listener    net.Listener
-	clients     map[string]*Client
-	games       map[string]*game.GameEngine
-	dataManager *game.DataManager
-	matchmaking *MatchmakingQueue
-	mu          sync.RWMutex
-	isRunning   bool
-	logger      *logger.Logger
+	address        string
+	listener       net.Listener
+	clients        map[string]*Client
+	games          map[string]*game.GameEngine
+	dataManager    *game.DataManager
+	matchmaking    *MatchmakingQueue
+	rulesets       map[string]game.Ruleset // Ruleset played in each game mode's queue
+	reconnectGrace time.Duration           // How long a dropped player has to log back in to their match
+	awayPlayers    map[string]*awayPlayer  // Players who dropped out of a match, by player ID
+	pauseMu        sync.Mutex              // Serializes pausing and resuming matches for away players
+	mu             sync.RWMutex
+	isRunning      bool
+	logger         *logger.Logger
 }
 
 // Client represents a connected client
@@ -42,24 +46,60 @@ type Client struct {
 
 // MatchmakingQueue handles player matchmaking
 type MatchmakingQueue struct {
-	simpleQueue   []*Client
-	enhancedQueue []*Client
-	mu            sync.Mutex
+	simpleQueue         []*Client
+	enhancedQueue       []*Client
+	correspondenceQueue []*Client
+	mu                  sync.Mutex
 }
 
 // NewServer creates a new TCP server instance
 func NewServer(address string, dataManager *game.DataManager) *Server {
+	// Each mode plays the ruleset of the same name until UseRuleset picks another
+	rulesets := make(map[string]game.Ruleset)
+	for _, mode := range []string{game.ModeSimple, game.ModeEnhanced} {
+		rulesets[mode], _ = dataManager.GetRuleset(mode)
+	}
+
 	return &Server{
 		address:     address,
 		clients:     make(map[string]*Client),
 		games:       make(map[string]*game.GameEngine),
 		dataManager: dataManager,
 		matchmaking: &MatchmakingQueue{
-			simpleQueue:   make([]*Client, 0),
-			enhancedQueue: make([]*Client, 0),
+			simpleQueue:         make([]*Client, 0),
+			enhancedQueue:       make([]*Client, 0),
+			correspondenceQueue: make([]*Client, 0),
 		},
-		logger: logger.Server,
+		rulesets:       rulesets,
+		reconnectGrace: DefaultReconnectGrace,
+		awayPlayers:    make(map[string]*awayPlayer),
+		logger:         logger.Server,
+	}
+}
+
+// UseRuleset makes the game mode's queue play the named ruleset. The
+// correspondence queue takes a Simple mode ruleset with a move deadline and
+// is closed until a ruleset is picked. Must be called before Start.
+func (s *Server) UseRuleset(gameMode, name string) error {
+	rules, err := s.dataManager.GetRuleset(name)
+	if err != nil {
+		return err
+	}
+
+	mode := gameMode
+	if gameMode == game.QueueCorrespondence {
+		mode = game.ModeSimple
+		if !rules.Correspondence() {
+			return fmt.Errorf("ruleset %q has no move_deadline_hours, it cannot be played by correspondence", name)
+		}
 	}
+	if rules.Mode != mode {
+		return fmt.Errorf("ruleset %q is for %s mode, not %s", name, rules.Mode, mode)
+	}
+
+	s.rulesets[gameMode] = rules
+	s.logger.Info("%s queue plays the %s ruleset", gameMode, rules.Name)
+	return nil
 }
 
 // Start begins listening for client connections
@@ -73,9 +113,13 @@ func (s *Server) Start() error {
 	s.isRunning = true
 	s.logger.Info("Server started and listening on %s", s.address)
 
+	// Pick up the correspondence games left open when the server last stopped
+	s.resumeCorrespondenceGames()
+
 	// Start background services
 	go s.matchmakingService()
 	go s.cleanupService()
+	go s.moveDeadlineService()
 
 	// Accept client connections
 	for s.isRunning {
@@ -154,8 +198,9 @@ func (s *Server) handleClient(conn net.Conn) {
 	}
 
 	// If client was in a game, handle game cleanup
+	var leaveGame func()
 	if client.GameID != "" {
-		s.handlePlayerDisconnect(client.GameID, client.ID)
+		leaveGame = s.handlePlayerDisconnect(client.GameID, client.ID)
 	}
 
 	// Remove from matchmaking queues
@@ -172,11 +217,24 @@ func (s *Server) handleClient(conn net.Conn) {
 			break
 		}
 	}
+	for i, c := range s.matchmaking.correspondenceQueue {
+		if c.ID == client.ID {
+			s.matchmaking.correspondenceQueue = append(s.matchmaking.correspondenceQueue[:i], s.matchmaking.correspondenceQueue[i+1:]...)
+			break
+		}
+	}
 	s.matchmaking.mu.Unlock()
 
-	delete(s.clients, client.ID)
+	// The player may already be back on a new connection under the same ID
+	if s.clients[client.ID] == client {
+		delete(s.clients, client.ID)
+	}
 	s.mu.Unlock()
 
+	if leaveGame != nil {
+		leaveGame()
+	}
+
 	conn.Close()
 	s.logger.Info("Client %s disconnected", client.ID)
 }
@@ -195,10 +253,22 @@ func (s *Server) processMessage(client *Client, data []byte) error {
 		return s.handleLogin(client, msg)
 	case network.MsgRegister:
 		return s.handleRegister(client, msg)
+	case network.MsgSaveDeck:
+		return s.handleSaveDeck(client, msg)
+	case network.MsgSelectDeck:
+		return s.handleSelectDeck(client, msg)
 	case network.MsgFindMatch:
 		return s.handleFindMatch(client, msg)
+	case network.MsgListGames:
+		return s.handleListGames(client, msg)
+	case network.MsgOpenGame:
+		return s.handleOpenGame(client, msg)
+	case network.MsgLeaveGame:
+		return s.handleLeaveGame(client, msg)
 	case network.MsgSummonTroop:
 		return s.handleSummonTroop(client, msg)
+	case network.MsgCastSpell:
+		return s.handleCastSpell(client, msg)
 	case network.MsgAttack:
 		return s.handleAttack(client, msg)
 	case network.MsgEndTurn:
@@ -230,9 +300,18 @@ func (s *Server) handleLogin(client *Client, msg *network.Message) error {
 
 	client.Username = username
 	client.Player = playerData
+	s.bindPlayerID(client)
+	rejoined := s.reattachPlayer(client)
 
 	s.logger.Info("Player %s logged in successfully", username)
-	return s.sendAuthResponse(client, true, client.ID, "Login successful", playerData)
+	if err := s.sendAuthResponse(client, true, client.ID, "Login successful", playerData); err != nil || rejoined == nil {
+		return err
+	}
+
+	// Back in the match they dropped out of
+	s.sendGameStartTo(client, rejoined, true)
+	s.notifyPlayerBack(client, rejoined)
+	return nil
 }
 
 // handleRegister processes registration requests
@@ -261,11 +340,85 @@ func (s *Server) handleRegister(client *Client, msg *network.Message) error {
 
 	client.Username = username
 	client.Player = playerData
+	s.bindPlayerID(client)
 
 	s.logger.Info("Player %s registered successfully", username)
 	return s.sendAuthResponse(client, true, client.ID, "Registration successful", playerData)
 }
 
+// bindPlayerID gives a logged in client the player ID of its account. It is
+// the same every session, so correspondence games know the player when they
+// come back. Only AuthenticatePlayer's IsActive check keeps two live
+// connections from binding the same ID: a second login is refused until the
+// first connection has logged out, and that connection's cleanup must not
+// remove an entry a newer login has since taken over.
+func (s *Server) bindPlayerID(client *Client) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.clients, client.ID)
+	client.ID = "player_" + client.Username
+	s.clients[client.ID] = client
+}
+
+// handleSaveDeck processes saving a named deck
+func (s *Server) handleSaveDeck(client *Client, msg *network.Message) error {
+	if client.Player == nil {
+		return s.sendError(client, "NOT_AUTHENTICATED", "Must login first")
+	}
+
+	deckReq, ok := msg.Data["save_deck_request"].(map[string]interface{})
+	if !ok {
+		return s.sendError(client, "INVALID_REQUEST", "Invalid save deck request format")
+	}
+	deckData, ok := deckReq["deck"].(map[string]interface{})
+	if !ok {
+		return s.sendError(client, "INVALID_REQUEST", "Invalid save deck request format")
+	}
+
+	deck := game.Deck{}
+	deck.Name, _ = deckData["name"].(string)
+	cards, _ := deckData["cards"].([]interface{})
+	for _, card := range cards {
+		name, _ := card.(string)
+		deck.Cards = append(deck.Cards, game.TroopType(name))
+	}
+
+	playerData, err := s.dataManager.SaveDeck(client.Username, deck)
+	if err != nil {
+		return s.sendError(client, "INVALID_DECK", err.Error())
+	}
+	client.Player = playerData
+
+	s.logger.Info("Player %s saved deck %q: %v", client.Username, deck.Name, deck.Cards)
+	return s.sendDeckUpdated(client, fmt.Sprintf("Deck %q saved", deck.Name))
+}
+
+// handleSelectDeck processes picking the active deck
+func (s *Server) handleSelectDeck(client *Client, msg *network.Message) error {
+	if client.Player == nil {
+		return s.sendError(client, "NOT_AUTHENTICATED", "Must login first")
+	}
+
+	selectReq, ok := msg.Data["select_deck_request"].(map[string]interface{})
+	if !ok {
+		return s.sendError(client, "INVALID_REQUEST", "Invalid select deck request format")
+	}
+	deckName, _ := selectReq["deck_name"].(string)
+
+	playerData, err := s.dataManager.SetActiveDeck(client.Username, deckName)
+	if err != nil {
+		return s.sendError(client, "INVALID_DECK", err.Error())
+	}
+	client.Player = playerData
+
+	message := fmt.Sprintf("Active deck: %s", deckName)
+	if deckName == "" {
+		message = "Active deck cleared, matches will use random troops"
+	}
+	return s.sendDeckUpdated(client, message)
+}
+
 // handleFindMatch processes matchmaking requests
 func (s *Server) handleFindMatch(client *Client, msg *network.Message) error {
 	if client.Player == nil {
@@ -278,8 +431,8 @@ func (s *Server) handleFindMatch(client *Client, msg *network.Message) error {
 	}
 
 	gameMode, _ := matchReq["game_mode"].(string)
-	if gameMode != game.ModeSimple && gameMode != game.ModeEnhanced {
-		return s.sendError(client, "INVALID_GAME_MODE", "Game mode must be 'simple' or 'enhanced'")
+	if _, ok := s.rulesets[gameMode]; !ok {
+		return s.sendError(client, "INVALID_GAME_MODE", "Game mode must be 'simple', 'enhanced' or 'correspondence' (when the server offers it)")
 	}
 
 	// Add to matchmaking queue
@@ -306,8 +459,9 @@ func (s *Server) handleSummonTroop(client *Client, msg *network.Message) error {
 	}
 
 	troopName := game.TroopType(summonReq["troop_name"].(string))
+	lane, _ := summonReq["lane"].(string)
 
-	action, err := gameEngine.SummonTroop(client.ID, troopName)
+	action, err := gameEngine.SummonTroop(client.ID, troopName, game.Lane(lane))
 	if err != nil {
 		return s.sendError(client, "SUMMON_FAILED", err.Error())
 	}
@@ -316,6 +470,30 @@ func (s *Server) handleSummonTroop(client *Client, msg *network.Message) error {
 	return s.broadcastGameEvent(client.GameID, *action, *gameEngine.GetGameState())
 }
 
+// handleCastSpell processes spell casting
+func (s *Server) handleCastSpell(client *Client, msg *network.Message) error {
+	gameEngine := s.getClientGame(client)
+	if gameEngine == nil {
+		return s.sendError(client, "NO_ACTIVE_GAME", "No active game found")
+	}
+
+	spellReq, ok := msg.Data["cast_spell_request"].(map[string]interface{})
+	if !ok {
+		return s.sendError(client, "INVALID_REQUEST", "Invalid cast spell request format")
+	}
+
+	spellName, _ := spellReq["spell_name"].(string)
+	targetName, _ := spellReq["target_name"].(string)
+
+	action, err := gameEngine.CastSpell(client.ID, game.SpellType(spellName), targetName)
+	if err != nil {
+		return s.sendError(client, "CAST_SPELL_FAILED", err.Error())
+	}
+
+	// Broadcast event to both players
+	return s.broadcastGameEvent(client.GameID, *action, *gameEngine.GetGameState())
+}
+
 // handleAttack processes attack actions
 func (s *Server) handleAttack(client *Client, msg *network.Message) error {
 	gameEngine := s.getClientGame(client)
@@ -368,6 +546,8 @@ func (s *Server) handleEndTurn(client *Client, msg *network.Message) error {
 	// Create turn change message
 	response := network.NewMessage(network.MsgTurnChange, "", client.GameID)
 	response.SetData("current_turn", updatedGameState.CurrentTurn)
+	response.SetData("turn_time_left", updatedGameState.TurnTimeLeft)
+	response.SetData("reason", game.TurnEndedByPlayer)
 	response.SetData("game_state", updatedGameState)
 
 	s.logger.Info("Turn switched from %s to %s", client.Username, updatedGameState.CurrentTurn)
@@ -393,8 +573,9 @@ func (s *Server) handleSurrender(client *Client, msg *network.Message) error {
 		return s.sendError(client, "SURRENDER_FAILED", err.Error())
 	}
 
+	// The engine's GAME_END event ends the game for both players
 	s.logger.Info("Player %s surrendered", client.Username)
-	return s.endGame(client.GameID, "surrender")
+	return nil
 }
 
 // handlePing processes ping messages
@@ -451,8 +632,18 @@ func (s *Server) sendError(client *Client, code, message string) error {
 	return s.sendMessage(client, errorMsg)
 }
 
+func (s *Server) sendDeckUpdated(client *Client, message string) error {
+	response := network.NewMessage(network.MsgDeckUpdated, client.ID, "")
+	response.SetData("deck_updated", network.DeckUpdatedResponse{
+		Message:    message,
+		PlayerData: client.Player,
+	})
+	return s.sendMessage(client, response)
+}
+
 func (s *Server) sendAuthResponse(client *Client, success bool, playerID, message string, playerData *game.PlayerData) error {
-	response := network.NewMessage(network.MsgAuthOK, playerID, "")
+	// The game ID tells a player who rejoins a match that its GAME_START follows
+	response := network.NewMessage(network.MsgAuthOK, playerID, client.GameID)
 	if !success {
 		response.Type = network.MsgAuthFail
 	}
@@ -462,6 +653,7 @@ func (s *Server) sendAuthResponse(client *Client, success bool, playerID, messag
 		PlayerID:   playerID,
 		Message:    message,
 		PlayerData: playerData,
+		DeckSize:   s.dataManager.DeckSize(),
 	})
 
 	return s.sendMessage(client, response)
@@ -491,22 +683,42 @@ func (s *Server) getClientGame(client *Client) *game.GameEngine {
 	return s.games[client.GameID]
 }
 
+func (s *Server) getGame(gameID string) *game.GameEngine {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.games[gameID]
+}
+
 func (s *Server) removeClient(clientID string) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
+	var leaveGame func()
 
+	s.mu.Lock()
 	if client, exists := s.clients[clientID]; exists {
 		if client.GameID != "" {
 			// Thông báo opponent win
-			s.handlePlayerDisconnect(client.GameID, clientID)
+			leaveGame = s.handlePlayerDisconnect(client.GameID, clientID)
 		}
 
 		client.IsActive = false
 		delete(s.clients, clientID)
 	}
+	s.mu.Unlock()
+
+	if leaveGame != nil {
+		leaveGame()
+	}
 }
 
 func (s *Server) cleanupInactiveClients() {
+	// Games the removed clients leave, handled once s.mu is released
+	var leaveGames []func()
+	defer func() {
+		for _, leaveGame := range leaveGames {
+			leaveGame()
+		}
+	}()
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -519,7 +731,9 @@ func (s *Server) cleanupInactiveClients() {
 			
 			// Handle game cleanup if client was in a game
 			if client.GameID != "" {
-				s.handlePlayerDisconnect(client.GameID, clientID)
+				if leaveGame := s.handlePlayerDisconnect(client.GameID, clientID); leaveGame != nil {
+					leaveGames = append(leaveGames, leaveGame)
+				}
 			}
 			
 			// Mark as inactive and remove from matchmaking queues
@@ -541,6 +755,12 @@ func (s *Server) cleanupInactiveClients() {
 					break
 				}
 			}
+			for i, c := range s.matchmaking.correspondenceQueue {
+				if c.ID == clientID {
+					s.matchmaking.correspondenceQueue = append(s.matchmaking.correspondenceQueue[:i], s.matchmaking.correspondenceQueue[i+1:]...)
+					break
+				}
+			}
 			s.matchmaking.mu.Unlock()
 			
 			s.logger.Info("Removed inactive client: %s", clientID)
@@ -559,8 +779,16 @@ func (s *Server) endGame(gameID string, reason string) error {
 
 	gameState := gameEngine.GetGameState()
 	delete(s.games, gameID) // Remove game from active games
+	s.cancelReconnectGrace(gameID)
 	s.mu.Unlock()
 
+	// A finished correspondence game is no longer open
+	if gameState.Rules.Correspondence() {
+		if err := s.dataManager.RemoveCorrespondenceGame(gameID); err != nil {
+			s.logger.Error("Failed to close correspondence game %s: %v", gameID, err)
+		}
+	}
+
 	s.logger.Info("🎯 Processing endGame for %s, winner: %s", gameID, gameState.Winner)
 
 	// Find clients in this game
@@ -577,22 +805,23 @@ func (s *Server) endGame(gameID string, reason string) error {
 	}
 	s.mu.RUnlock()
 
+	// Players who are away (correspondence, or dropped and not back in time)
+	// see the result in their profile
 	if client1 == nil || client2 == nil {
-		s.logger.Error("❌ Cannot find clients for game %s", gameID)
-		return fmt.Errorf("clients not found")
+		s.logger.Info("Not every player of game %s is connected for its end", gameID)
 	}
 
 	var player1EXP, player2EXP int
 
 	if gameState.Winner == "draw" {
-		player1EXP = s.dataManager.CalculateGameEndEXP(false, true) // Draw
-		player2EXP = s.dataManager.CalculateGameEndEXP(false, true) // Draw
+		player1EXP = gameState.Rules.GameEndEXP(false, true) // Draw
+		player2EXP = gameState.Rules.GameEndEXP(false, true) // Draw
 	} else if gameState.Winner == gameState.Player1.ID {
-		player1EXP = s.dataManager.CalculateGameEndEXP(true, false)  // Win
-		player2EXP = s.dataManager.CalculateGameEndEXP(false, false) // Lose
+		player1EXP = gameState.Rules.GameEndEXP(true, false)  // Win
+		player2EXP = gameState.Rules.GameEndEXP(false, false) // Lose
 	} else {
-		player1EXP = s.dataManager.CalculateGameEndEXP(false, false) // Lose
-		player2EXP = s.dataManager.CalculateGameEndEXP(true, false)  // Win
+		player1EXP = gameState.Rules.GameEndEXP(false, false) // Lose
+		player2EXP = gameState.Rules.GameEndEXP(true, false)  // Win
 	}
 
 	s.logger.Info("📊 EXP calculated - Player1: %d, Player2: %d", player1EXP, player2EXP)
@@ -605,7 +834,6 @@ func (s *Server) endGame(gameID string, reason string) error {
 		if err != nil {
 			s.logger.Error("❌ Failed to send game end to %s: %v", client1.Username, err)
 		}
-		client1.GameID = ""
 	}
 
 	if client2 != nil {
@@ -616,9 +844,16 @@ func (s *Server) endGame(gameID string, reason string) error {
 		if err != nil {
 			s.logger.Error("❌ Failed to send game end to %s: %v", client2.Username, err)
 		}
-		client2.GameID = ""
 	}
 
+	s.mu.Lock()
+	for _, client := range []*Client{client1, client2} {
+		if client != nil {
+			client.GameID = ""
+		}
+	}
+	s.mu.Unlock()
+
 	s.logger.Info("✅ Game %s ended successfully: winner=%s, reason=%s", gameID, gameState.Winner, reason)
 	return nil
 }
@@ -705,9 +940,12 @@ func (mq *MatchmakingQueue) AddPlayer(client *Client, gameMode string) {
 	mq.mu.Lock()
 	defer mq.mu.Unlock()
 
-	if gameMode == game.ModeSimple {
+	switch gameMode {
+	case game.ModeSimple:
 		mq.simpleQueue = append(mq.simpleQueue, client)
-	} else {
+	case game.QueueCorrespondence:
+		mq.correspondenceQueue = append(mq.correspondenceQueue, client)
+	default:
 		mq.enhancedQueue = append(mq.enhancedQueue, client)
 	}
 }
@@ -734,18 +972,61 @@ func (mq *MatchmakingQueue) ProcessMatches(server *Server) {
 
 		go server.createMatch(player1, player2, game.ModeEnhanced)
 	}
+
+	// Process correspondence queue
+	if len(mq.correspondenceQueue) >= 2 {
+		player1 := mq.correspondenceQueue[0]
+		player2 := mq.correspondenceQueue[1]
+		mq.correspondenceQueue = mq.correspondenceQueue[2:]
+
+		go server.createMatch(player1, player2, game.QueueCorrespondence)
+	}
 }
 
 // createMatch creates a new game between two players
 func (s *Server) createMatch(client1, client2 *Client, gameMode string) {
-	gameID := fmt.Sprintf("game_%d", time.Now().Unix())
-
-	// Create players for game
-	gamePlayer1 := s.dataManager.CreatePlayerForGame(client1.Player, client1.ID)
-	gamePlayer2 := s.dataManager.CreatePlayerForGame(client2.Player, client2.ID)
+	// Every match gets its own seed so it can be reproduced later
+	seed := time.Now().UnixNano()
+	rng := game.NewMatchRNG(seed)
+
+	// Create players for game, rejecting decks that are no longer valid
+	gamePlayer1, err1 := s.dataManager.CreatePlayerForGame(client1.Player, client1.ID, rng)
+	gamePlayer2, err2 := s.dataManager.CreatePlayerForGame(client2.Player, client2.ID, rng)
+	if err1 != nil || err2 != nil {
+		s.cancelMatch(gameMode, map[*Client]error{client1: err1, client2: err2})
+		return
+	}
 
 	// Create game engine
-	gameEngine := game.NewGameEngine(gamePlayer1, gamePlayer2, gameMode, s.dataManager.GetGameSpecs(), s.dataManager)
+	gameEngine := game.NewGameEngine(gamePlayer1, gamePlayer2, s.rulesets[gameMode], s.dataManager.GetGameSpecs(), s.dataManager, seed)
+
+	// Use the engine's game ID so events from the engine reach the right clients
+	gameID := gameEngine.GetGameState().ID
+
+	// Record the match so it can be replayed and verified later
+	recorder, err := game.NewReplayRecorder(s.dataManager.ReplayDir(), gameID)
+	if err != nil {
+		s.logger.Warn("Replay recording disabled for %s: %v", gameID, err)
+	} else {
+		gameEngine.SetRecorder(recorder)
+	}
+
+	// A correspondence game is saved as its replay between turns, it can't be played without one
+	if s.rulesets[gameMode].Correspondence() {
+		if err == nil {
+			err = s.saveMoveDeadline(gameEngine.GetGameState())
+		}
+		if err != nil {
+			s.logger.Error("Cannot save correspondence game %s: %v", gameID, err)
+			if recorder != nil {
+				recorder.Close()
+			}
+			for _, client := range []*Client{client1, client2} {
+				s.sendError(client, "MATCH_FAILED", "Could not save the correspondence game, please try again later")
+			}
+			return
+		}
+	}
 
 	// Store game
 	s.mu.Lock()
@@ -765,11 +1046,27 @@ func (s *Server) createMatch(client1, client2 *Client, gameMode string) {
 	s.logger.Info("Match created: %s vs %s in %s mode", client1.Username, client2.Username, gameMode)
 }
 
+// cancelMatch calls off a match that could not start. Players whose deck was
+// rejected are told why, their opponents go back into the queue.
+func (s *Server) cancelMatch(gameMode string, deckErrors map[*Client]error) {
+	for client, err := range deckErrors {
+		if err != nil {
+			s.logger.Warn("Match cancelled, invalid deck for %s: %v", client.Username, err)
+			s.sendError(client, "INVALID_DECK", fmt.Sprintf("Match cancelled: %v", err))
+			continue
+		}
+
+		s.matchmaking.AddPlayer(client, gameMode)
+		s.sendError(client, "MATCH_CANCELLED", "Opponent's deck was rejected, searching for a new match...")
+	}
+}
+
 // handleGameEvents listens to game engine events and broadcasts them
 func (s *Server) handleGameEvents(gameEngine *game.GameEngine) {
 	eventChan := gameEngine.GetEventChannel()
 
-	for s.isRunning && gameEngine.IsRunning() {
+	// Keep reading after the engine stops so its final GAME_END is not lost
+	for s.isRunning {
 		select {
 		case event := <-eventChan:
 			gameState := gameEngine.GetGameState()
@@ -800,8 +1097,17 @@ func (s *Server) handleGameEvents(gameEngine *game.GameEngine) {
 			if event.Type == "TURN_END" {
 				response := network.NewMessage(network.MsgTurnChange, "", gameState.ID)
 				response.SetData("current_turn", gameState.CurrentTurn)
+				response.SetData("turn_time_left", gameState.TurnTimeLeft)
+				response.SetData("reason", event.Data["reason"])
 				response.SetData("game_state", gameState)
 				s.broadcastToGame(gameState.ID, response)
+
+				// The next player's move deadline starts now
+				if gameState.Rules.Correspondence() {
+					if err := s.saveMoveDeadline(gameState); err != nil {
+						s.logger.Error("Failed to save move deadline for %s: %v", gameState.ID, err)
+					}
+				}
 			}
 
 			if event.Type == "EXP_GAINED" {
@@ -809,7 +1115,13 @@ func (s *Server) handleGameEvents(gameEngine *game.GameEngine) {
 			}
 
 		case <-time.After(100 * time.Millisecond):
-			if gameEngine.GetGameState().TimeLeft <= 0 && gameEngine.IsRunning() {
+			if !gameEngine.IsRunning() {
+				// Engine finished without us seeing a GAME_END event
+				s.endGame(gameEngine.GetGameState().ID, "unknown")
+				return
+			}
+			// Only Enhanced matches have a match clock
+			if state := gameEngine.GetGameState(); state.GameMode == game.ModeEnhanced && state.TimeLeft <= 0 {
 				s.logger.Info("🚨 Backup timeout detected, forcing game end...")
 				s.endGame(gameEngine.GetGameState().ID, "timeout")
 				return
@@ -827,7 +1139,7 @@ func (s *Server) notifyMatchFound(client1, client2 *Client, gameID, gameMode str
 		"game_id":   gameID,
 		"opponent":  map[string]interface{}{"username": client2.Username, "level": client2.Player.Level},
 		"game_mode": gameMode,
-		"your_turn": gameMode == game.ModeSimple,
+		"your_turn": s.rulesets[gameMode].Mode == game.ModeSimple,
 	})
 	s.sendMessage(client1, msg1)
 
@@ -844,30 +1156,46 @@ func (s *Server) notifyMatchFound(client1, client2 *Client, gameID, gameMode str
 
 // sendGameStart sends game initialization data to both players
 func (s *Server) sendGameStart(client1, client2 *Client, gameEngine *game.GameEngine) {
+	s.sendGameStartTo(client1, gameEngine, false)
+	s.sendGameStartTo(client2, gameEngine, false)
+}
+
+// sendGameStartTo sends one player the current state of their game. resumed
+// is set for a game picked up again rather than just started.
+func (s *Server) sendGameStartTo(client *Client, gameEngine *game.GameEngine, resumed bool) {
 	gameState := gameEngine.GetGameState()
 
-	// Send to player 1
-	msg1 := network.NewMessage(network.MsgGameStart, client1.ID, gameState.ID)
-	msg1.SetData("game_start", map[string]interface{}{
-		"game_state":        gameState,
-		"your_troops":       gameState.Player1.Troops,
-		"your_towers":       gameState.Player1.Towers,
-		"countdown_seconds": 3,
-	})
-	s.sendMessage(client1, msg1)
+	player := gameState.Player1
+	if client.ID == gameState.Player2.ID {
+		player = gameState.Player2
+	}
 
-	// Send to player 2
-	msg2 := network.NewMessage(network.MsgGameStart, client2.ID, gameState.ID)
-	msg2.SetData("game_start", map[string]interface{}{
+	msg := network.NewMessage(network.MsgGameStart, client.ID, gameState.ID)
+	msg.SetData("game_start", map[string]interface{}{
 		"game_state":        gameState,
-		"your_troops":       gameState.Player2.Troops,
-		"your_towers":       gameState.Player2.Towers,
+		"your_troops":       player.Troops,
+		"your_towers":       player.Towers,
 		"countdown_seconds": 3,
+		"resumed":           resumed,
 	})
-	s.sendMessage(client2, msg2)
+	s.sendMessage(client, msg)
 }
 
-func (s *Server) handlePlayerDisconnect(gameID, disconnectedClientID string) {
+// handlePlayerDisconnect deals with a player who left their game. Callers
+// hold s.mu, and call the returned func, when not nil, once they released it:
+// it waits on the game engine's loop.
+func (s *Server) handlePlayerDisconnect(gameID, disconnectedClientID string) func() {
+	// A correspondence game waits for the player to come back
+	if gameEngine, exists := s.games[gameID]; exists && gameEngine.GetGameState().Rules.Correspondence() {
+		s.logger.Info("Player %s left correspondence game %s, it stays open", disconnectedClientID, gameID)
+		return nil
+	}
+
+	// Otherwise the player gets a grace period to log back in
+	if gameEngine, exists := s.games[gameID]; exists && s.reconnectGrace > 0 && gameEngine.IsRunning() {
+		return s.startReconnectGrace(gameEngine, disconnectedClientID)
+	}
+
 	// Tìm opponent
 	for _, client := range s.clients {
 		if client.GameID == gameID && client.ID != disconnectedClientID && client.IsActive {
@@ -886,9 +1214,14 @@ func (s *Server) handlePlayerDisconnect(gameID, disconnectedClientID string) {
 		}
 	}
 
-	// Remove game
+	// Remove the game, its engine loop is stopped once s.mu is released
+	gameEngine, exists := s.games[gameID]
 	delete(s.games, gameID)
 	s.logger.Info("Game %s ended due to player disconnect", gameID)
+	if !exists {
+		return nil
+	}
+	return gameEngine.StopGame
 }
 
 func (s *Server) handleManaUpdate(gameID string, player1Mana, player2Mana, timeLeft int) {